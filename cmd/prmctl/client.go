@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"prmanager/internal/models"
+	"strings"
+	"time"
+)
+
+// client - обертка над /api/v1 сервиса
+type client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+func newClient(cfg *config) *client {
+	return &client{
+		baseURL: strings.TrimRight(cfg.Server, "/") + "/api/v1",
+		token:   cfg.Token,
+		http:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// apiError - ошибка из ответа сервиса {"error": {"code", "message"}}
+type apiError struct {
+	Status  int
+	Code    string
+	Message string
+	Fields  []struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	}
+}
+
+func (e *apiError) Error() string {
+	msg := fmt.Sprintf("%s: %s (HTTP %d)", e.Code, e.Message, e.Status)
+	for _, field := range e.Fields {
+		msg += fmt.Sprintf("\n  %s: %s", field.Field, field.Message)
+	}
+	return msg
+}
+
+// do выполняет запрос к path (относительно /api/v1) и декодирует ответ в out
+func (c *client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reqBody)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		var errResp struct {
+			Error struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+				Fields  []struct {
+					Field   string `json:"field"`
+					Message string `json:"message"`
+				} `json:"fields"`
+			} `json:"error"`
+		}
+		apiErr := &apiError{Status: resp.StatusCode, Code: "HTTP_ERROR", Message: resp.Status}
+		if json.NewDecoder(resp.Body).Decode(&errResp) == nil && errResp.Error.Code != "" {
+			apiErr.Code = errResp.Error.Code
+			apiErr.Message = errResp.Error.Message
+			apiErr.Fields = errResp.Error.Fields
+		}
+		return apiErr
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// eachPullRequestPage запрашивает страницы /pull-requests, пока next вернет true
+// и есть следующая страница
+func (c *client) eachPullRequestPage(ctx context.Context, query url.Values, next func(*models.PullRequestPage) bool) error {
+	for {
+		var page models.PullRequestPage
+		if err := c.do(ctx, "GET", "/pull-requests", query, nil, &page); err != nil {
+			return err
+		}
+		if !next(&page) || page.NextCursor == "" {
+			return nil
+		}
+		query.Set("cursor", page.NextCursor)
+	}
+}
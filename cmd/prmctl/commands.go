@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/url"
+	"prmanager/internal/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func (c *cli) teamAdd(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("team add", flag.ContinueOnError)
+	var members stringList
+	fs.Var(&members, "member", "")
+	strategy := fs.String("strategy", "", "")
+	positional, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	type member struct {
+		UserID   string `json:"user_id"`
+		Username string `json:"username"`
+		IsActive bool   `json:"is_active"`
+	}
+	body := struct {
+		TeamName           string   `json:"team_name"`
+		AssignmentStrategy string   `json:"assignment_strategy,omitempty"`
+		Members            []member `json:"members"`
+	}{TeamName: positional[0], AssignmentStrategy: *strategy, Members: []member{}}
+
+	for _, value := range members {
+		id, username, ok := strings.Cut(value, "=")
+		if !ok || id == "" || username == "" {
+			return fmt.Errorf("%w: --member must be <id>=<username>, got %q", errUsage, value)
+		}
+		body.Members = append(body.Members, member{UserID: id, Username: username, IsActive: true})
+	}
+
+	var created models.Team
+	if err := c.client.do(ctx, "POST", "/teams", nil, body, &created); err != nil {
+		return err
+	}
+	return c.printTeam(&created)
+}
+
+func (c *cli) teamGet(ctx context.Context, args []string) error {
+	positional, err := parseFlags(flag.NewFlagSet("team get", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+
+	var team models.Team
+	if err := c.client.do(ctx, "GET", "/teams/"+url.PathEscape(positional[0]), nil, nil, &team); err != nil {
+		return err
+	}
+	return c.printTeam(&team)
+}
+
+func (c *cli) userSetActive(ctx context.Context, args []string, active bool) error {
+	positional, err := parseFlags(flag.NewFlagSet("user", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]bool{"is_active": active}
+	var user models.User
+	if err := c.client.do(ctx, "PATCH", "/users/"+url.PathEscape(positional[0]), nil, body, &user); err != nil {
+		return err
+	}
+
+	if c.json {
+		return c.printJSON(user)
+	}
+	return c.printTable([]string{"USER_ID", "USERNAME", "TEAM", "ACTIVE"},
+		[][]string{{user.UserID, user.Username, user.TeamName, strconv.FormatBool(user.IsActive)}})
+}
+
+func (c *cli) prCreate(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("pr create", flag.ContinueOnError)
+	id := fs.String("id", "", "")
+	name := fs.String("name", "", "")
+	author := fs.String("author", "", "")
+	repository := fs.String("repository", "", "")
+	number := fs.Int("number", 0, "")
+	var files stringList
+	fs.Var(&files, "file", "")
+	if _, err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+	if *name == "" || *author == "" {
+		return fmt.Errorf("%w: --name and --author are required", errUsage)
+	}
+
+	body := map[string]any{
+		"pull_request_id":   *id,
+		"pull_request_name": *name,
+		"author_id":         *author,
+		"repository":        *repository,
+		"number":            *number,
+		"files":             []string(files),
+	}
+	var pr models.PullRequest
+	if err := c.client.do(ctx, "POST", "/pull-requests", nil, body, &pr); err != nil {
+		return err
+	}
+	return c.printPullRequest(&pr)
+}
+
+func (c *cli) prMerge(ctx context.Context, args []string) error {
+	positional, err := parseFlags(flag.NewFlagSet("pr merge", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+
+	var pr models.PullRequest
+	if err := c.client.do(ctx, "POST", "/pull-requests/"+url.PathEscape(positional[0])+"/merge", nil, nil, &pr); err != nil {
+		return err
+	}
+	return c.printPullRequest(&pr)
+}
+
+func (c *cli) prReassign(ctx context.Context, args []string) error {
+	positional, err := parseFlags(flag.NewFlagSet("pr reassign", flag.ContinueOnError), args, 2)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]string{"old_user_id": positional[1]}
+	var result models.ReassignResult
+	if err := c.client.do(ctx, "POST", "/pull-requests/"+url.PathEscape(positional[0])+"/reassign", nil, body, &result); err != nil {
+		return err
+	}
+
+	if c.json {
+		return c.printJSON(result)
+	}
+	if err := c.printPullRequests([]*models.PullRequest{result.PR}); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "\n%s replaced by %s\n", positional[1], result.NewReviewerID)
+	return nil
+}
+
+// pullRequestFilterFlags - фильтры списка PR, общие для pr list и stats
+type pullRequestFilterFlags struct {
+	author, team, repository *string
+	from, to                 *string
+}
+
+func addPullRequestFilterFlags(fs *flag.FlagSet) *pullRequestFilterFlags {
+	return &pullRequestFilterFlags{
+		author:     fs.String("author", "", ""),
+		team:       fs.String("team", "", ""),
+		repository: fs.String("repository", "", ""),
+		from:       fs.String("from", "", ""),
+		to:         fs.String("to", "", ""),
+	}
+}
+
+func (f *pullRequestFilterFlags) query() url.Values {
+	query := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	set("author_id", *f.author)
+	set("team_name", *f.team)
+	set("repository", *f.repository)
+	set("created_from", *f.from)
+	set("created_to", *f.to)
+	return query
+}
+
+func (c *cli) prList(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("pr list", flag.ContinueOnError)
+	filter := addPullRequestFilterFlags(fs)
+	status := fs.String("status", "", "")
+	reviewer := fs.String("reviewer", "", "")
+	search := fs.String("q", "", "")
+	limit := fs.Int("limit", 50, "")
+	all := fs.Bool("all", false, "")
+	if _, err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+
+	query := filter.query()
+	if *status != "" {
+		query.Set("status", *status)
+	}
+	if *reviewer != "" {
+		query.Set("reviewer_id", *reviewer)
+	}
+	if *search != "" {
+		query.Set("q", *search)
+	}
+	query.Set("limit", strconv.Itoa(*limit))
+
+	var prs []*models.PullRequest
+	var nextCursor string
+	err := c.client.eachPullRequestPage(ctx, query, func(page *models.PullRequestPage) bool {
+		for _, item := range page.PullRequests {
+			prs = append(prs, &item.PullRequest)
+		}
+		nextCursor = page.NextCursor
+		return *all
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.json {
+		return c.printJSON(map[string]any{"pull_requests": nonNil(prs), "next_cursor": nextCursor})
+	}
+	if err := c.printPullRequests(prs); err != nil {
+		return err
+	}
+	if nextCursor != "" {
+		fmt.Fprintln(c.out, "\nmore results available, use --all to fetch every page")
+	}
+	return nil
+}
+
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultServer = "http://localhost:8080"
+
+// config - настройки клиента. Приоритет: флаги, переменные PRMCTL_*, файл конфигурации
+type config struct {
+	Server string `yaml:"server"`
+	Token  string `yaml:"token"`
+	// Output - формат вывода: table (по умолчанию) или json
+	Output string `yaml:"output"`
+}
+
+// loadConfig читает файл конфигурации и накладывает на него переменные окружения.
+// Отсутствие файла по умолчанию (~/.config/prmctl/config.yaml) не ошибка,
+// явно заданного через --config или PRMCTL_CONFIG - ошибка.
+func loadConfig(path string) (*config, error) {
+	cfg := &config{}
+
+	explicit := path != ""
+	if !explicit {
+		path = os.Getenv("PRMCTL_CONFIG")
+		explicit = path != ""
+	}
+	if !explicit {
+		if dir, err := os.UserConfigDir(); err == nil {
+			path = filepath.Join(dir, "prmctl", "config.yaml")
+		}
+	}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := yaml.Unmarshal(data, cfg); err != nil {
+				return nil, fmt.Errorf("parse config %s: %w", path, err)
+			}
+		case explicit || !errors.Is(err, fs.ErrNotExist):
+			return nil, fmt.Errorf("read config: %w", err)
+		}
+	}
+
+	if value := os.Getenv("PRMCTL_SERVER"); value != "" {
+		cfg.Server = value
+	}
+	if value := os.Getenv("PRMCTL_TOKEN"); value != "" {
+		cfg.Token = value
+	}
+	if value := os.Getenv("PRMCTL_OUTPUT"); value != "" {
+		cfg.Output = value
+	}
+
+	return cfg, nil
+}
+
+// validate подставляет значения по умолчанию и проверяет формат вывода
+func (c *config) validate() error {
+	if c.Server == "" {
+		c.Server = defaultServer
+	}
+	if c.Output == "" {
+		c.Output = "table"
+	}
+	if c.Output != "table" && c.Output != "json" {
+		return fmt.Errorf("output must be table or json, got %q", c.Output)
+	}
+	return nil
+}
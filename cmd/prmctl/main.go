@@ -0,0 +1,172 @@
+// prmctl - консольный клиент HTTP API сервиса назначения ревьюеров.
+// Адрес сервера и токен берутся из флагов, переменных PRMCTL_* или файла конфигурации.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+)
+
+const usage = `Usage: prmctl [flags] <command> [args]
+
+Commands:
+  team add <team> --member <id>=<username> [--member ...] [--strategy random|round_robin]
+  team get <team>
+  user activate <user_id>
+  user deactivate <user_id>
+  pr create --name <title> --author <user_id> [--id <id>] [--repository <repo> --number <n>] [--file <path> ...]
+  pr merge <pull_request_id>
+  pr reassign <pull_request_id> <old_user_id>
+  pr list [--status open|merged] [--author <id>] [--team <team>] [--reviewer <id>] [--repository <repo>] [--q <text>] [--limit <n>] [--all]
+  stats [--team <team>] [--author <id>] [--repository <repo>] [--from <date>] [--to <date>]
+
+Flags:
+  --config <path>   config file (PRMCTL_CONFIG, default ~/.config/prmctl/config.yaml)
+  --server <url>    server URL (PRMCTL_SERVER, default http://localhost:8080)
+  --token <token>   bearer token (PRMCTL_TOKEN)
+  -o, --output      table or json (PRMCTL_OUTPUT, default table)
+`
+
+// errUsage - неверные аргументы; печатается справка
+var errUsage = errors.New("invalid arguments")
+
+// cli - общее состояние подкоманд
+type cli struct {
+	client *client
+	out    io.Writer
+	json   bool
+}
+
+func main() {
+	global := flag.NewFlagSet("prmctl", flag.ContinueOnError)
+	global.SetOutput(io.Discard)
+	configPath := global.String("config", "", "")
+	server := global.String("server", "", "")
+	token := global.String("token", "", "")
+	var output string
+	global.StringVar(&output, "output", "", "")
+	global.StringVar(&output, "o", "", "")
+
+	if err := global.Parse(os.Args[1:]); err != nil || global.NArg() == 0 {
+		if err != nil && !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "prmctl: %v\n", err)
+		}
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "prmctl: %v\n", err)
+		os.Exit(1)
+	}
+	if *server != "" {
+		cfg.Server = *server
+	}
+	if *token != "" {
+		cfg.Token = *token
+	}
+	if output != "" {
+		cfg.Output = output
+	}
+	if err := cfg.validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "prmctl: %v\n", err)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	c := &cli{
+		client: newClient(cfg),
+		out:    os.Stdout,
+		json:   cfg.Output == "json",
+	}
+
+	if err := c.run(ctx, global.Args()); err != nil {
+		if errors.Is(err, errUsage) {
+			if err != errUsage {
+				fmt.Fprintf(os.Stderr, "prmctl: %v\n\n", err)
+			}
+			fmt.Fprint(os.Stderr, usage)
+			os.Exit(2)
+		}
+		fmt.Fprintf(os.Stderr, "prmctl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func (c *cli) run(ctx context.Context, args []string) error {
+	command, args := args[0], args[1:]
+
+	var sub string
+	if command != "stats" {
+		if len(args) == 0 {
+			return errUsage
+		}
+		sub, args = args[0], args[1:]
+	}
+
+	switch command + " " + sub {
+	case "team add":
+		return c.teamAdd(ctx, args)
+	case "team get":
+		return c.teamGet(ctx, args)
+	case "user activate":
+		return c.userSetActive(ctx, args, true)
+	case "user deactivate":
+		return c.userSetActive(ctx, args, false)
+	case "pr create":
+		return c.prCreate(ctx, args)
+	case "pr merge":
+		return c.prMerge(ctx, args)
+	case "pr reassign":
+		return c.prReassign(ctx, args)
+	case "pr list":
+		return c.prList(ctx, args)
+	case "stats ":
+		return c.stats(ctx, args)
+	}
+	return errUsage
+}
+
+// parseFlags разбирает флаги подкоманды; позиционные аргументы могут идти
+// до флагов (prmctl team add backend --member ...). want - число позиционных аргументов.
+func parseFlags(fs *flag.FlagSet, args []string, want int) ([]string, error) {
+	fs.SetOutput(io.Discard)
+
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, fmt.Errorf("%w: %v", errUsage, err)
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+
+	if len(positional) != want {
+		return nil, errUsage
+	}
+	return positional, nil
+}
+
+// stringList - повторяемый флаг (--member, --file)
+type stringList []string
+
+func (l *stringList) String() string {
+	return fmt.Sprint(*l)
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
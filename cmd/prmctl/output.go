@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"prmanager/internal/models"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+func (c *cli) printJSON(v any) error {
+	encoder := json.NewEncoder(c.out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// printTable печатает строки с выравниванием колонок
+func (c *cli) printTable(header []string, rows [][]string) error {
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+func (c *cli) printTeam(team *models.Team) error {
+	if c.json {
+		return c.printJSON(team)
+	}
+
+	strategy := team.AssignmentStrategy
+	if strategy == "" {
+		strategy = "random"
+	}
+	fmt.Fprintf(c.out, "Team %s (%s)\n\n", team.TeamName, strategy)
+
+	rows := make([][]string, 0, len(team.Members))
+	for _, member := range team.Members {
+		maxOpen := "-"
+		if member.MaxOpenReviews != nil {
+			maxOpen = strconv.Itoa(*member.MaxOpenReviews)
+		}
+		rows = append(rows, []string{
+			member.UserID,
+			member.Username,
+			strconv.FormatBool(member.IsActive),
+			strconv.FormatBool(member.IsTrainee),
+			strconv.FormatFloat(member.ReviewWeight, 'g', -1, 64),
+			maxOpen,
+		})
+	}
+	return c.printTable([]string{"USER_ID", "USERNAME", "ACTIVE", "TRAINEE", "WEIGHT", "MAX_OPEN"}, rows)
+}
+
+func (c *cli) printPullRequest(pr *models.PullRequest) error {
+	if c.json {
+		return c.printJSON(pr)
+	}
+	return c.printPullRequests([]*models.PullRequest{pr})
+}
+
+// printPullRequests печатает PR таблицей; JSON списков формирует вызывающий
+func (c *cli) printPullRequests(prs []*models.PullRequest) error {
+	rows := make([][]string, 0, len(prs))
+	for _, pr := range prs {
+		reviewers := strings.Join(pr.AssignedReviewers, ",")
+		if reviewers == "" {
+			reviewers = "-"
+		}
+		if pr.PendingAssignment {
+			reviewers += " (pending)"
+		}
+		rows = append(rows, []string{
+			pr.PullRequestID,
+			pr.PullRequestName,
+			pr.AuthorID,
+			pr.Status,
+			reviewers,
+			formatTime(&pr.CreatedAt),
+			formatTime(pr.MergedAt),
+		})
+	}
+	return c.printTable([]string{"ID", "NAME", "AUTHOR", "STATUS", "REVIEWERS", "CREATED", "MERGED"}, rows)
+}
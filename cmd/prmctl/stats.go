@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"prmanager/internal/models"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// stats - сводка по PR, посчитанная клиентом по страницам /pull-requests
+type stats struct {
+	PullRequests int `json:"pull_requests"`
+	Open         int `json:"open"`
+	Merged       int `json:"merged"`
+	// Pending - открытые PR в очереди на добор ревьюеров
+	Pending int `json:"pending_assignment"`
+	// Время от создания до мержа смерженных PR, в часах
+	MedianMergeHours  float64         `json:"median_merge_hours"`
+	AverageMergeHours float64         `json:"average_merge_hours"`
+	Reviewers         []reviewerStats `json:"reviewers"`
+	Authors           []authorStats   `json:"authors"`
+}
+
+type reviewerStats struct {
+	UserID string `json:"user_id"`
+	// Assigned - всего назначений, Open - из них на открытых PR
+	Assigned int `json:"assigned"`
+	Open     int `json:"open"`
+}
+
+type authorStats struct {
+	UserID       string `json:"user_id"`
+	PullRequests int    `json:"pull_requests"`
+	Merged       int    `json:"merged"`
+}
+
+func (c *cli) stats(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	filter := addPullRequestFilterFlags(fs)
+	if _, err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+
+	query := filter.query()
+	query.Set("limit", "200")
+
+	var prs []*models.PullRequest
+	err := c.client.eachPullRequestPage(ctx, query, func(page *models.PullRequestPage) bool {
+		for _, item := range page.PullRequests {
+			prs = append(prs, &item.PullRequest)
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	result := aggregateStats(prs)
+	if c.json {
+		return c.printJSON(result)
+	}
+	return c.printStats(result)
+}
+
+func aggregateStats(prs []*models.PullRequest) *stats {
+	result := &stats{
+		PullRequests: len(prs),
+		Reviewers:    []reviewerStats{},
+		Authors:      []authorStats{},
+	}
+
+	reviewers := make(map[string]*reviewerStats)
+	authors := make(map[string]*authorStats)
+	var mergeTimes []time.Duration
+
+	for _, pr := range prs {
+		author, ok := authors[pr.AuthorID]
+		if !ok {
+			author = &authorStats{UserID: pr.AuthorID}
+			authors[pr.AuthorID] = author
+		}
+		author.PullRequests++
+
+		open := pr.Status == "OPEN"
+		if open {
+			result.Open++
+			if pr.PendingAssignment {
+				result.Pending++
+			}
+		} else {
+			result.Merged++
+			author.Merged++
+			if pr.MergedAt != nil {
+				mergeTimes = append(mergeTimes, pr.MergedAt.Sub(pr.CreatedAt))
+			}
+		}
+
+		for _, reviewerID := range pr.AssignedReviewers {
+			reviewer, ok := reviewers[reviewerID]
+			if !ok {
+				reviewer = &reviewerStats{UserID: reviewerID}
+				reviewers[reviewerID] = reviewer
+			}
+			reviewer.Assigned++
+			if open {
+				reviewer.Open++
+			}
+		}
+	}
+
+	if len(mergeTimes) > 0 {
+		sort.Slice(mergeTimes, func(i, j int) bool { return mergeTimes[i] < mergeTimes[j] })
+
+		var total time.Duration
+		for _, d := range mergeTimes {
+			total += d
+		}
+		result.AverageMergeHours = hours(total / time.Duration(len(mergeTimes)))
+
+		middle := len(mergeTimes) / 2
+		median := mergeTimes[middle]
+		if len(mergeTimes)%2 == 0 {
+			median = (mergeTimes[middle-1] + mergeTimes[middle]) / 2
+		}
+		result.MedianMergeHours = hours(median)
+	}
+
+	for _, reviewer := range reviewers {
+		result.Reviewers = append(result.Reviewers, *reviewer)
+	}
+	sort.Slice(result.Reviewers, func(i, j int) bool {
+		a, b := result.Reviewers[i], result.Reviewers[j]
+		if a.Assigned != b.Assigned {
+			return a.Assigned > b.Assigned
+		}
+		return a.UserID < b.UserID
+	})
+
+	for _, author := range authors {
+		result.Authors = append(result.Authors, *author)
+	}
+	sort.Slice(result.Authors, func(i, j int) bool {
+		a, b := result.Authors[i], result.Authors[j]
+		if a.PullRequests != b.PullRequests {
+			return a.PullRequests > b.PullRequests
+		}
+		return a.UserID < b.UserID
+	})
+
+	return result
+}
+
+// hours округляет длительность до сотых часа
+func hours(d time.Duration) float64 {
+	return float64(d.Round(36*time.Second)) / float64(time.Hour)
+}
+
+func (c *cli) printStats(result *stats) error {
+	fmt.Fprintf(c.out, "Pull requests: %d (open %d, merged %d, pending assignment %d)\n",
+		result.PullRequests, result.Open, result.Merged, result.Pending)
+	if result.Merged > 0 {
+		fmt.Fprintf(c.out, "Time to merge: median %.2fh, average %.2fh\n",
+			result.MedianMergeHours, result.AverageMergeHours)
+	}
+
+	if len(result.Reviewers) > 0 {
+		fmt.Fprintln(c.out)
+		rows := make([][]string, 0, len(result.Reviewers))
+		for _, reviewer := range result.Reviewers {
+			rows = append(rows, []string{reviewer.UserID, strconv.Itoa(reviewer.Assigned), strconv.Itoa(reviewer.Open)})
+		}
+		if err := c.printTable([]string{"REVIEWER", "ASSIGNED", "OPEN"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if len(result.Authors) > 0 {
+		fmt.Fprintln(c.out)
+		rows := make([][]string, 0, len(result.Authors))
+		for _, author := range result.Authors {
+			rows = append(rows, []string{author.UserID, strconv.Itoa(author.PullRequests), strconv.Itoa(author.Merged)})
+		}
+		if err := c.printTable([]string{"AUTHOR", "PULL_REQUESTS", "MERGED"}, rows); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"prmanager/internal/config"
+	"prmanager/internal/models"
+	"prmanager/internal/repository"
+	"prmanager/internal/service"
+)
+
+// runExport выгружает все данные сервиса в JSON-архив: export <path>
+func runExport(logger *log.Logger, cfg *config.Config, args []string) {
+	if len(args) != 1 {
+		logger.Fatalf("Usage: %s export <path.json>", os.Args[0])
+	}
+
+	dbPool := connectDB(logger, cfg.Database)
+	defer dbPool.Close()
+
+	repo := repository.NewRepository(dbPool, logger)
+	svc := service.NewService(repo, logger, serviceOptions(logger, cfg)...)
+
+	archive, err := svc.ExportArchive(context.Background())
+	if err != nil {
+		logger.Fatalf("Export failed: %v", err)
+	}
+
+	file, err := os.Create(args[0])
+	if err != nil {
+		logger.Fatalf("Unable to create archive: %v", err)
+	}
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(archive); err != nil {
+		logger.Fatalf("Unable to write archive: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		logger.Fatalf("Unable to write archive: %v", err)
+	}
+
+	logger.Printf("Exported %d teams, %d users, %d pull requests to %s",
+		len(archive.Teams), len(archive.Users), len(archive.PullRequests), args[0])
+}
+
+// runRestore восстанавливает архив export в пустую базу: restore <path>
+func runRestore(logger *log.Logger, cfg *config.Config, args []string) {
+	if len(args) != 1 {
+		logger.Fatalf("Usage: %s restore <path.json>", os.Args[0])
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		logger.Fatalf("Unable to open archive: %v", err)
+	}
+	defer file.Close()
+
+	var archive models.Archive
+	if err := json.NewDecoder(file).Decode(&archive); err != nil {
+		logger.Fatalf("Unable to parse archive: %v", err)
+	}
+
+	dbPool := connectDB(logger, cfg.Database)
+	defer dbPool.Close()
+
+	repo := repository.NewRepository(dbPool, logger)
+	svc := service.NewService(repo, logger, serviceOptions(logger, cfg)...)
+
+	summary, err := svc.RestoreArchive(context.Background(), &archive)
+	if err != nil {
+		logger.Fatalf("Restore failed: %v", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(summary)
+}
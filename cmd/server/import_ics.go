@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"prmanager/internal/config"
+	"prmanager/internal/repository"
+	"prmanager/internal/service"
+)
+
+// runImportICS импортирует периоды отсутствия из .ics файла: import-ics <path>
+func runImportICS(logger *log.Logger, cfg *config.Config, args []string) {
+	if len(args) != 1 {
+		logger.Fatalf("Usage: %s import-ics <path.ics>", os.Args[0])
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		logger.Fatalf("Unable to open calendar: %v", err)
+	}
+	defer file.Close()
+
+	dbPool := connectDB(logger, cfg.Database)
+	defer dbPool.Close()
+
+	repo := repository.NewRepository(dbPool, logger)
+	svc := service.NewService(repo, logger, serviceOptions(logger, cfg)...)
+
+	result, err := svc.ImportCalendar(context.Background(), file)
+	if err != nil {
+		logger.Fatalf("Import failed: %v", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(result)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"prmanager/internal/config"
+	"prmanager/internal/migrate"
+	"prmanager/migrations"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// runMigrate управляет схемой базы: migrate up|down|status
+func runMigrate(logger *log.Logger, cfg *config.Config, args []string) {
+	if len(args) != 1 {
+		logger.Fatalf("Usage: %s migrate up|down|status", os.Args[0])
+	}
+
+	dbPool := connectDB(logger, cfg.Database)
+	defer dbPool.Close()
+
+	migrator := newMigrator(logger, dbPool)
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			logger.Fatalf("Migration failed: %v", err)
+		}
+		logger.Printf("Applied %d migrations", applied)
+	case "down":
+		migration, err := migrator.Down(ctx)
+		if err != nil {
+			logger.Fatalf("Rollback failed: %v", err)
+		}
+		if migration == nil {
+			logger.Println("No migrations to roll back")
+			return
+		}
+		logger.Printf("Rolled back migration %s", migration.Name)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			logger.Fatalf("Migration status failed: %v", err)
+		}
+
+		table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(table, "MIGRATION\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "Pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(table, "%s\t%s\n", status.Name, appliedAt)
+		}
+		table.Flush()
+	default:
+		logger.Fatalf("Unknown migrate command: %s", args[0])
+	}
+}
+
+// autoMigrate применяет миграции при старте сервера (database.auto_migrate)
+func autoMigrate(logger *log.Logger, dbPool *pgxpool.Pool) {
+	applied, err := newMigrator(logger, dbPool).Up(context.Background())
+	if err != nil {
+		logger.Fatalf("Migration failed: %v", err)
+	}
+	logger.Printf("Database schema is up to date, applied %d migrations", applied)
+}
+
+func newMigrator(logger *log.Logger, dbPool *pgxpool.Pool) *migrate.Migrator {
+	migrator, err := migrate.New(dbPool, migrations.FS, logger)
+	if err != nil {
+		logger.Fatalf("Invalid embedded migrations: %v", err)
+	}
+	return migrator
+}
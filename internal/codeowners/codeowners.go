@@ -0,0 +1,107 @@
+// Package codeowners разбирает файлы владельцев кода в формате GitHub CODEOWNERS.
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"prmanager/internal/pathmatch"
+	"strings"
+)
+
+// Rule - строка файла: шаблон пути и его владельцы.
+// Правило без владельцев снимает владение с подходящих путей.
+type Rule struct {
+	Pattern string
+	Owners  []string
+	Line    int
+}
+
+// File - разобранный CODEOWNERS. Правила хранятся в порядке следования в файле.
+type File struct {
+	Rules []Rule
+}
+
+// Parse читает CODEOWNERS. Пустые строки и комментарии (#) пропускаются,
+// "\#" в начале шаблона экранирует решетку. Владельцы записываются без "@":
+// "@alice" -> "alice", "@org/backend" -> "org/backend", e-mail остается как есть.
+func Parse(r io.Reader) (*File, error) {
+	var file File
+
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+
+		fields := splitFields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		pattern := fields[0]
+		if strings.HasPrefix(pattern, "!") {
+			return nil, fmt.Errorf("line %d: negated patterns are not supported", lineNo)
+		}
+		if strings.Contains(pattern, "[") {
+			return nil, fmt.Errorf("line %d: character ranges are not supported", lineNo)
+		}
+
+		rule := Rule{Pattern: pattern, Line: lineNo}
+		for _, owner := range fields[1:] {
+			rule.Owners = append(rule.Owners, strings.TrimPrefix(owner, "@"))
+		}
+
+		file.Rules = append(file.Rules, rule)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read codeowners: %w", err)
+	}
+
+	return &file, nil
+}
+
+// Owners возвращает владельцев пути по последнему подходящему правилу (как в GitHub).
+// Пустой результат означает, что у пути нет владельцев.
+func (f *File) Owners(path string) []string {
+	for i := len(f.Rules) - 1; i >= 0; i-- {
+		if pathmatch.Match(f.Rules[i].Pattern, path) {
+			return f.Rules[i].Owners
+		}
+	}
+	return nil
+}
+
+// splitFields делит строку по пробелам с учетом "\ " и "\#" и отбрасывает комментарий.
+func splitFields(line string) []string {
+	var (
+		fields  []string
+		current strings.Builder
+	)
+
+	flush := func() {
+		if current.Len() > 0 {
+			fields = append(fields, current.String())
+			current.Reset()
+		}
+	}
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\' && i+1 < len(line) && (line[i+1] == ' ' || line[i+1] == '#'):
+			current.WriteByte(line[i+1])
+			i++
+		case c == '#' && current.Len() == 0:
+			flush()
+			return fields
+		case c == ' ' || c == '\t':
+			flush()
+		default:
+			current.WriteByte(c)
+		}
+	}
+	flush()
+
+	return fields
+}
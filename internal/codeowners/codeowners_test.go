@@ -0,0 +1,88 @@
+package codeowners
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func parseFile(t *testing.T, name string) (*File, error) {
+	t.Helper()
+
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatalf("open %s: %v", name, err)
+	}
+	defer f.Close()
+
+	return Parse(f)
+}
+
+func TestParseSample(t *testing.T) {
+	file, err := parseFile(t, "testdata/CODEOWNERS")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	want := []Rule{
+		{Pattern: "*", Owners: []string{"alice"}, Line: 2},
+		{Pattern: "*.go", Owners: []string{"go-team-lead"}, Line: 5},
+		{Pattern: "/docs/", Owners: []string{"org/docs", "writer@example.com"}, Line: 8},
+		{Pattern: "build/", Owners: []string{"bob"}, Line: 11},
+		{Pattern: "/services/**/migrations", Owners: []string{"org/dba"}, Line: 14},
+		{Pattern: "/My Documents/", Owners: []string{"carol"}, Line: 17},
+		{Pattern: "#notes.txt", Owners: []string{"dave"}, Line: 20},
+		{Pattern: "/docs/generated/", Owners: nil, Line: 23},
+		{Pattern: "/main.go", Owners: []string{"eve"}, Line: 26},
+	}
+	if !reflect.DeepEqual(file.Rules, want) {
+		t.Errorf("Rules =\n%+v\nwant\n%+v", file.Rules, want)
+	}
+}
+
+func TestOwners(t *testing.T) {
+	file, err := parseFile(t, "testdata/CODEOWNERS")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{"default rule", "README.md", []string{"alice"}},
+		{"last match wins over default", "internal/service/service.go", []string{"go-team-lead"}},
+		{"later anchored file wins over earlier glob", "main.go", []string{"eve"}},
+		{"anchored file does not match deeper", "cmd/main.go", []string{"go-team-lead"}},
+		{"anchored directory", "docs/api/index.md", []string{"org/docs", "writer@example.com"}},
+		{"anchored directory only at root", "internal/docs/index.md", []string{"alice"}},
+		{"unanchored directory at any depth", "cmd/server/build/out.txt", []string{"bob"}},
+		{"directory pattern does not match file", "build", []string{"alice"}},
+		{"double star zero segments", "services/migrations/001.sql", []string{"org/dba"}},
+		{"double star many segments", "services/billing/db/migrations/001.sql", []string{"org/dba"}},
+		{"double star anchored", "legacy/services/billing/migrations/001.sql", []string{"alice"}},
+		{"escaped space", "My Documents/plan.txt", []string{"carol"}},
+		{"escaped hash", "#notes.txt", []string{"dave"}},
+		{"rule without owners", "docs/generated/api.md", nil},
+		{"rule without owners overrides earlier glob", "docs/generated/gen.go", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := file.Owners(tt.path); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Owners(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, name := range []string{"testdata/invalid_negation", "testdata/invalid_range"} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseFile(t, name); err == nil {
+				t.Errorf("Parse(%s) succeeded, want error", name)
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+// Package config - конфигурация сервера: YAML-файл и переопределения из
+// переменных окружения. Значение берется из окружения, затем из файла,
+// затем по умолчанию; итог проверяется целиком до запуска.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Уровни логирования
+const (
+	// LogLevelDebug - как info, плюс строка на каждый HTTP-запрос
+	LogLevelDebug = "debug"
+	// LogLevelInfo - ход операций сервиса и фоновых задач
+	LogLevelInfo = "info"
+	// LogLevelError - только ошибки
+	LogLevelError = "error"
+)
+
+type Config struct {
+	Server      ServerConfig      `yaml:"server"`
+	Database    DatabaseConfig    `yaml:"database"`
+	Log         LogConfig         `yaml:"log"`
+	Assignment  AssignmentConfig  `yaml:"assignment"`
+	Jobs        JobsConfig        `yaml:"jobs"`
+	Idempotency IdempotencyConfig `yaml:"idempotency"`
+	Admin       AdminConfig       `yaml:"admin"`
+	Features    FeaturesConfig    `yaml:"features"`
+}
+
+type ServerConfig struct {
+	Addr              string        `yaml:"addr" env:"SERVER_ADDR"`
+	GRPCAddr          string        `yaml:"grpc_addr" env:"GRPC_ADDR"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"SERVER_READ_HEADER_TIMEOUT"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"SERVER_READ_TIMEOUT"`
+	// WriteTimeout - 0 без ограничения; ограничивает и длительность потоков /events
+	WriteTimeout    time.Duration `yaml:"write_timeout" env:"SERVER_WRITE_TIMEOUT"`
+	IdleTimeout     time.Duration `yaml:"idle_timeout" env:"SERVER_IDLE_TIMEOUT"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT"`
+}
+
+type DatabaseConfig struct {
+	// URL - строка подключения PostgreSQL; значения по умолчанию нет
+	URL string `yaml:"url" env:"DATABASE_URL"`
+	// MaxConns - 0 означает значение pgxpool по умолчанию
+	MaxConns        int32         `yaml:"max_conns" env:"DB_MAX_CONNS"`
+	MinConns        int32         `yaml:"min_conns" env:"DB_MIN_CONNS"`
+	MaxConnLifetime time.Duration `yaml:"max_conn_lifetime" env:"DB_MAX_CONN_LIFETIME"`
+	MaxConnIdleTime time.Duration `yaml:"max_conn_idle_time" env:"DB_MAX_CONN_IDLE_TIME"`
+	ConnectTimeout  time.Duration `yaml:"connect_timeout" env:"DB_CONNECT_TIMEOUT"`
+	// AutoMigrate - применять встроенные миграции при старте сервера
+	AutoMigrate bool `yaml:"auto_migrate" env:"DB_AUTO_MIGRATE"`
+}
+
+type LogConfig struct {
+	Level string `yaml:"level" env:"LOG_LEVEL"`
+}
+
+type AssignmentConfig struct {
+	// DefaultReviewerCount - сколько ревьюеров назначается на PR вне репозитория
+	DefaultReviewerCount int `yaml:"default_reviewer_count" env:"ASSIGNMENT_DEFAULT_REVIEWER_COUNT"`
+	// Seed - фиксированный seed генератора (replay), nil - случайный
+	Seed *int64 `yaml:"seed" env:"ASSIGNMENT_SEED"`
+	// Deterministic - выбор ревьюеров зависит только от id PR и seed
+	Deterministic bool `yaml:"deterministic" env:"ASSIGNMENT_DETERMINISTIC"`
+}
+
+type JobsConfig struct {
+	// PendingAssignmentInterval - как часто воркер перебирает очередь без сигнала
+	PendingAssignmentInterval  time.Duration `yaml:"pending_assignment_interval" env:"PENDING_ASSIGNMENT_INTERVAL"`
+	IdempotencyCleanupInterval time.Duration `yaml:"idempotency_cleanup_interval" env:"IDEMPOTENCY_CLEANUP_INTERVAL"`
+}
+
+type IdempotencyConfig struct {
+	TTL time.Duration `yaml:"ttl" env:"IDEMPOTENCY_TTL"`
+	// LockTimeout - через сколько ключ запроса, который перестали продлевать
+	// (упавшая реплика), можно занять снова; не меньше server.write_timeout
+	LockTimeout time.Duration `yaml:"lock_timeout" env:"IDEMPOTENCY_LOCK_TIMEOUT"`
+}
+
+type AdminConfig struct {
+	// Token - bearer-токен для /admin/*; обязателен, если включен features.admin_api
+	Token string `yaml:"token" env:"ADMIN_TOKEN"`
+}
+
+// FeaturesConfig - отключаемые части сервиса
+type FeaturesConfig struct {
+	GRPC                    bool `yaml:"grpc" env:"FEATURE_GRPC"`
+	Events                  bool `yaml:"events" env:"FEATURE_EVENTS"`
+	Idempotency             bool `yaml:"idempotency" env:"FEATURE_IDEMPOTENCY"`
+	AdminAPI                bool `yaml:"admin_api" env:"FEATURE_ADMIN_API"`
+	PendingAssignmentWorker bool `yaml:"pending_assignment_worker" env:"FEATURE_PENDING_ASSIGNMENT_WORKER"`
+}
+
+// Default - значения по умолчанию; включено все, кроме /admin/*, которому нужен токен
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Addr:              ":8080",
+			GRPCAddr:          ":9090",
+			ReadHeaderTimeout: 10 * time.Second,
+			ReadTimeout:       30 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+			ShutdownTimeout:   5 * time.Second,
+		},
+		Database: DatabaseConfig{
+			MaxConnLifetime: time.Hour,
+			MaxConnIdleTime: 30 * time.Minute,
+			ConnectTimeout:  5 * time.Second,
+		},
+		Log: LogConfig{
+			Level: LogLevelInfo,
+		},
+		Assignment: AssignmentConfig{
+			DefaultReviewerCount: 2,
+		},
+		Jobs: JobsConfig{
+			PendingAssignmentInterval:  time.Minute,
+			IdempotencyCleanupInterval: time.Hour,
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:         24 * time.Hour,
+			LockTimeout: 2 * time.Minute,
+		},
+		Features: FeaturesConfig{
+			GRPC:                    true,
+			Events:                  true,
+			Idempotency:             true,
+			PendingAssignmentWorker: true,
+		},
+	}
+}
+
+// Load читает файл path (пустой - без файла), применяет переменные окружения
+// и проверяет результат. Неизвестные ключи файла - ошибка.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read config: %w", err)
+		}
+
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("parse config %s: %w", path, err)
+		}
+	}
+
+	if err := applyEnv(reflect.ValueOf(cfg).Elem()); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Validate проверяет всю конфигурацию и возвращает все ошибки сразу
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Server.Addr != "", "server.addr (SERVER_ADDR) is required")
+	check(!c.Features.GRPC || c.Server.GRPCAddr != "", "server.grpc_addr (GRPC_ADDR) is required when features.grpc is enabled")
+	check(c.Server.ReadHeaderTimeout >= 0, "server.read_header_timeout must not be negative")
+	check(c.Server.ReadTimeout >= 0, "server.read_timeout must not be negative")
+	check(c.Server.WriteTimeout >= 0, "server.write_timeout must not be negative")
+	check(c.Server.IdleTimeout >= 0, "server.idle_timeout must not be negative")
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout must be positive")
+
+	check(c.Database.URL != "", "database.url (DATABASE_URL) is required")
+	check(c.Database.MaxConns >= 0, "database.max_conns must not be negative")
+	check(c.Database.MinConns >= 0, "database.min_conns must not be negative")
+	check(c.Database.MaxConns == 0 || c.Database.MinConns <= c.Database.MaxConns,
+		"database.min_conns (%d) must not exceed database.max_conns (%d)", c.Database.MinConns, c.Database.MaxConns)
+	check(c.Database.MaxConnLifetime >= 0, "database.max_conn_lifetime must not be negative")
+	check(c.Database.MaxConnIdleTime >= 0, "database.max_conn_idle_time must not be negative")
+	check(c.Database.ConnectTimeout >= 0, "database.connect_timeout must not be negative")
+
+	switch c.Log.Level {
+	case LogLevelDebug, LogLevelInfo, LogLevelError:
+	default:
+		check(false, "log.level (LOG_LEVEL) must be debug, info or error, got %q", c.Log.Level)
+	}
+
+	check(c.Assignment.DefaultReviewerCount >= 0, "assignment.default_reviewer_count must not be negative")
+
+	check(c.Jobs.PendingAssignmentInterval > 0, "jobs.pending_assignment_interval must be positive")
+	check(c.Jobs.IdempotencyCleanupInterval > 0, "jobs.idempotency_cleanup_interval must be positive")
+	check(c.Idempotency.TTL > 0, "idempotency.ttl must be positive")
+	check(c.Idempotency.LockTimeout > 0, "idempotency.lock_timeout must be positive")
+	check(c.Server.WriteTimeout == 0 || c.Idempotency.LockTimeout >= c.Server.WriteTimeout,
+		"idempotency.lock_timeout (%s) must not be shorter than server.write_timeout (%s)", c.Idempotency.LockTimeout, c.Server.WriteTimeout)
+	check(c.Idempotency.LockTimeout <= c.Idempotency.TTL, "idempotency.lock_timeout must not exceed idempotency.ttl")
+
+	check(!c.Features.AdminAPI || c.Admin.Token != "", "admin.token (ADMIN_TOKEN) is required when features.admin_api is enabled")
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid config:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// applyEnv обходит структуру и подставляет значения переменных из тегов env
+func applyEnv(v reflect.Value) error {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		key := v.Type().Field(i).Tag.Get("env")
+
+		if key == "" {
+			if field.Kind() == reflect.Struct {
+				if err := applyEnv(field); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		value, ok := os.LookupEnv(key)
+		if !ok || value == "" {
+			continue
+		}
+		if err := setValue(field, value); err != nil {
+			return fmt.Errorf("invalid %s=%q: %w", key, value, err)
+		}
+	}
+	return nil
+}
+
+func setValue(field reflect.Value, value string) error {
+	if field.Kind() == reflect.Pointer {
+		ptr := reflect.New(field.Type().Elem())
+		if err := setValue(ptr.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(ptr)
+		return nil
+	}
+
+	if field.Type() == durationType {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return errors.New("must be a duration like 30s or 5m")
+		}
+		field.SetInt(int64(duration))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.New("must be true or false")
+		}
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return errors.New("must be an integer")
+		}
+		field.SetInt(parsed)
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package grpcserver
+
+import (
+	"prmanager/internal/grpc_server/pb"
+	"prmanager/internal/models"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func teamFromProto(team *pb.Team) *models.Team {
+	result := &models.Team{
+		TeamName:           team.GetTeamName(),
+		AssignmentStrategy: team.GetAssignmentStrategy(),
+		Members:            []models.User{},
+	}
+	for _, member := range team.GetMembers() {
+		result.Members = append(result.Members, models.User{
+			UserID:    member.GetUserId(),
+			Username:  member.GetUsername(),
+			TeamName:  team.GetTeamName(),
+			IsActive:  member.GetIsActive(),
+			Expertise: member.GetExpertise(),
+		})
+	}
+	return result
+}
+
+func teamToProto(team *models.Team) *pb.Team {
+	result := &pb.Team{
+		TeamName:           team.TeamName,
+		AssignmentStrategy: team.AssignmentStrategy,
+	}
+	for i := range team.Members {
+		result.Members = append(result.Members, userToProto(&team.Members[i]))
+	}
+	return result
+}
+
+func userToProto(user *models.User) *pb.User {
+	result := &pb.User{
+		UserId:       user.UserID,
+		Username:     user.Username,
+		TeamName:     user.TeamName,
+		IsActive:     user.IsActive,
+		ReviewWeight: user.ReviewWeight,
+		IsTrainee:    user.IsTrainee,
+		Expertise:    user.Expertise,
+	}
+	if user.MaxOpenReviews != nil {
+		value := int32(*user.MaxOpenReviews)
+		result.MaxOpenReviews = &value
+	}
+	return result
+}
+
+func pullRequestToProto(pr *models.PullRequest) *pb.PullRequest {
+	result := &pb.PullRequest{
+		PullRequestId:     pr.PullRequestID,
+		PullRequestName:   pr.PullRequestName,
+		AuthorId:          pr.AuthorID,
+		Status:            pr.Status,
+		Repository:        pr.Repository,
+		Number:            int32(pr.Number),
+		AssignedReviewers: pr.AssignedReviewers,
+		Files:             pr.Files,
+		PendingAssignment: pr.PendingAssignment,
+		CreatedAt:         timeToProto(&pr.CreatedAt),
+		MergedAt:          timeToProto(pr.MergedAt),
+	}
+	for _, shadow := range pr.ShadowReviewers {
+		result.ShadowReviewers = append(result.ShadowReviewers, &pb.ShadowReviewer{
+			UserId:   shadow.UserID,
+			MentorId: shadow.MentorID,
+		})
+	}
+	return result
+}
+
+func userReviewToProto(review *models.UserReview) *pb.UserReview {
+	return &pb.UserReview{
+		PullRequestId:   review.PullRequestID,
+		PullRequestName: review.PullRequestName,
+		AuthorId:        review.AuthorID,
+		Status:          review.Status,
+		Repository:      review.Repository,
+		CreatedAt:       timeToProto(&review.CreatedAt),
+		AssignedAt:      timeToProto(&review.AssignedAt),
+		MergedAt:        timeToProto(review.MergedAt),
+	}
+}
+
+func understaffedToProto(pr *models.UnderstaffedPullRequest) *pb.UnderstaffedPullRequest {
+	return &pb.UnderstaffedPullRequest{
+		PullRequestId:   pr.PullRequestID,
+		PullRequestName: pr.PullRequestName,
+		AuthorId:        pr.AuthorID,
+		Status:          pr.Status,
+		DesiredCount:    int32(pr.DesiredCount),
+		AssignedCount:   int32(pr.AssignedCount),
+		QueuedAt:        timeToProto(&pr.QueuedAt),
+		Attempts:        int32(pr.Attempts),
+		LastAttemptAt:   timeToProto(pr.LastAttemptAt),
+	}
+}
+
+// timeToProto - nil для отсутствующего и нулевого времени
+func timeToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func timeFromProto(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
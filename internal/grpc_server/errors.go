@@ -0,0 +1,55 @@
+package grpcserver
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain - домен ErrorInfo; Reason содержит код ошибки сервиса (NOT_FOUND, PR_MERGED, ...)
+const errorDomain = "prmanager"
+
+// serviceErrors - gRPC-код и сообщение для кодов ошибок сервиса
+var serviceErrors = map[string]struct {
+	code    codes.Code
+	message string
+}{
+	"INVALID_REQUEST":      {codes.InvalidArgument, "invalid request"},
+	"INVALID_CURSOR":       {codes.InvalidArgument, "cursor is invalid or does not match the query"},
+	"INVALID_STRATEGY":     {codes.InvalidArgument, "assignment_strategy must be random or round_robin"},
+	"NOT_FOUND":            {codes.NotFound, "resource not found"},
+	"TEAM_NOT_FOUND":       {codes.NotFound, "team not found"},
+	"AUTHOR_NOT_FOUND":     {codes.NotFound, "author not found"},
+	"REPOSITORY_NOT_FOUND": {codes.NotFound, "repository not found"},
+	"TEAM_EXISTS":          {codes.AlreadyExists, "team already exists"},
+	"PR_EXISTS":            {codes.AlreadyExists, "pull request already exists"},
+	"PR_MERGED":            {codes.FailedPrecondition, "pull request is merged"},
+	"NOT_ASSIGNED":         {codes.FailedPrecondition, "reviewer is not assigned to this pull request"},
+	"NO_CANDIDATE":         {codes.FailedPrecondition, "no active replacement candidate in team"},
+}
+
+// toStatus переводит ошибку сервиса в gRPC-статус; неизвестные ошибки - Internal
+func (s *Server) toStatus(err error) error {
+	reason := err.Error()
+	mapped, ok := serviceErrors[reason]
+	if !ok {
+		s.logger.Printf("gRPC internal error: %v", err)
+		return status.Error(codes.Internal, "internal server error")
+	}
+
+	s.logger.Printf("gRPC Error: %s - %s (code: %s)", reason, mapped.message, mapped.code)
+	return withReason(mapped.code, mapped.message, reason)
+}
+
+func (s *Server) invalidArgument(message string) error {
+	s.logger.Printf("gRPC Error: INVALID_REQUEST - %s", message)
+	return withReason(codes.InvalidArgument, message, "INVALID_REQUEST")
+}
+
+func withReason(code codes.Code, message, reason string) error {
+	st := status.New(code, message)
+	if detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
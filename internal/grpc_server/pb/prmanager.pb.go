@@ -0,0 +1,2019 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: prmanager/v1/prmanager.proto
+
+// API сервиса назначения ревьюеров для внутренних инструментов.
+// Повторяет HTTP-маршруты: команды, пользователи, PR и переназначение.
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type User struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	TeamName string                 `protobuf:"bytes,3,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	IsActive bool                   `protobuf:"varint,4,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	// Не задан - без ограничения
+	MaxOpenReviews *int32   `protobuf:"varint,5,opt,name=max_open_reviews,json=maxOpenReviews,proto3,oneof" json:"max_open_reviews,omitempty"`
+	ReviewWeight   float64  `protobuf:"fixed64,6,opt,name=review_weight,json=reviewWeight,proto3" json:"review_weight,omitempty"`
+	IsTrainee      bool     `protobuf:"varint,7,opt,name=is_trainee,json=isTrainee,proto3" json:"is_trainee,omitempty"`
+	Expertise      []string `protobuf:"bytes,8,rep,name=expertise,proto3" json:"expertise,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *User) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *User) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *User) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *User) GetMaxOpenReviews() int32 {
+	if x != nil && x.MaxOpenReviews != nil {
+		return *x.MaxOpenReviews
+	}
+	return 0
+}
+
+func (x *User) GetReviewWeight() float64 {
+	if x != nil {
+		return x.ReviewWeight
+	}
+	return 0
+}
+
+func (x *User) GetIsTrainee() bool {
+	if x != nil {
+		return x.IsTrainee
+	}
+	return false
+}
+
+func (x *User) GetExpertise() []string {
+	if x != nil {
+		return x.Expertise
+	}
+	return nil
+}
+
+type Team struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TeamName string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Members  []*User                `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	// random или round_robin
+	AssignmentStrategy string `protobuf:"bytes,3,opt,name=assignment_strategy,json=assignmentStrategy,proto3" json:"assignment_strategy,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Team) Reset() {
+	*x = Team{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Team) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Team) ProtoMessage() {}
+
+func (x *Team) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Team.ProtoReflect.Descriptor instead.
+func (*Team) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Team) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *Team) GetMembers() []*User {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *Team) GetAssignmentStrategy() string {
+	if x != nil {
+		return x.AssignmentStrategy
+	}
+	return ""
+}
+
+type ShadowReviewer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MentorId      string                 `protobuf:"bytes,2,opt,name=mentor_id,json=mentorId,proto3" json:"mentor_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShadowReviewer) Reset() {
+	*x = ShadowReviewer{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShadowReviewer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShadowReviewer) ProtoMessage() {}
+
+func (x *ShadowReviewer) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShadowReviewer.ProtoReflect.Descriptor instead.
+func (*ShadowReviewer) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ShadowReviewer) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ShadowReviewer) GetMentorId() string {
+	if x != nil {
+		return x.MentorId
+	}
+	return ""
+}
+
+type PullRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId        string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	// OPEN или MERGED
+	Status            string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Repository        string                 `protobuf:"bytes,5,opt,name=repository,proto3" json:"repository,omitempty"`
+	Number            int32                  `protobuf:"varint,6,opt,name=number,proto3" json:"number,omitempty"`
+	AssignedReviewers []string               `protobuf:"bytes,7,rep,name=assigned_reviewers,json=assignedReviewers,proto3" json:"assigned_reviewers,omitempty"`
+	ShadowReviewers   []*ShadowReviewer      `protobuf:"bytes,8,rep,name=shadow_reviewers,json=shadowReviewers,proto3" json:"shadow_reviewers,omitempty"`
+	Files             []string               `protobuf:"bytes,9,rep,name=files,proto3" json:"files,omitempty"`
+	PendingAssignment bool                   `protobuf:"varint,10,opt,name=pending_assignment,json=pendingAssignment,proto3" json:"pending_assignment,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	MergedAt          *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=merged_at,json=mergedAt,proto3" json:"merged_at,omitempty"`
+	// Релевантность; только в результатах поиска
+	Rank          *float64 `protobuf:"fixed64,13,opt,name=rank,proto3,oneof" json:"rank,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PullRequest) Reset() {
+	*x = PullRequest{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullRequest) ProtoMessage() {}
+
+func (x *PullRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullRequest.ProtoReflect.Descriptor instead.
+func (*PullRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PullRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *PullRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PullRequest) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *PullRequest) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *PullRequest) GetAssignedReviewers() []string {
+	if x != nil {
+		return x.AssignedReviewers
+	}
+	return nil
+}
+
+func (x *PullRequest) GetShadowReviewers() []*ShadowReviewer {
+	if x != nil {
+		return x.ShadowReviewers
+	}
+	return nil
+}
+
+func (x *PullRequest) GetFiles() []string {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *PullRequest) GetPendingAssignment() bool {
+	if x != nil {
+		return x.PendingAssignment
+	}
+	return false
+}
+
+func (x *PullRequest) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PullRequest) GetMergedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MergedAt
+	}
+	return nil
+}
+
+func (x *PullRequest) GetRank() float64 {
+	if x != nil && x.Rank != nil {
+		return *x.Rank
+	}
+	return 0
+}
+
+type CreateTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTeamRequest) Reset() {
+	*x = CreateTeamRequest{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTeamRequest) ProtoMessage() {}
+
+func (x *CreateTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTeamRequest.ProtoReflect.Descriptor instead.
+func (*CreateTeamRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateTeamRequest) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+type GetTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamRequest) Reset() {
+	*x = GetTeamRequest{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamRequest) ProtoMessage() {}
+
+func (x *GetTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamRequest.ProtoReflect.Descriptor instead.
+func (*GetTeamRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+type SetTeamStrategyRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TeamName           string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	AssignmentStrategy string                 `protobuf:"bytes,2,opt,name=assignment_strategy,json=assignmentStrategy,proto3" json:"assignment_strategy,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SetTeamStrategyRequest) Reset() {
+	*x = SetTeamStrategyRequest{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTeamStrategyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTeamStrategyRequest) ProtoMessage() {}
+
+func (x *SetTeamStrategyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTeamStrategyRequest.ProtoReflect.Descriptor instead.
+func (*SetTeamStrategyRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SetTeamStrategyRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *SetTeamStrategyRequest) GetAssignmentStrategy() string {
+	if x != nil {
+		return x.AssignmentStrategy
+	}
+	return ""
+}
+
+type SetUserActiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IsActive      bool                   `protobuf:"varint,2,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserActiveRequest) Reset() {
+	*x = SetUserActiveRequest{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserActiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserActiveRequest) ProtoMessage() {}
+
+func (x *SetUserActiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserActiveRequest.ProtoReflect.Descriptor instead.
+func (*SetUserActiveRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SetUserActiveRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetUserActiveRequest) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+type SetUserExpertiseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Expertise     []string               `protobuf:"bytes,2,rep,name=expertise,proto3" json:"expertise,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserExpertiseRequest) Reset() {
+	*x = SetUserExpertiseRequest{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserExpertiseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserExpertiseRequest) ProtoMessage() {}
+
+func (x *SetUserExpertiseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserExpertiseRequest.ProtoReflect.Descriptor instead.
+func (*SetUserExpertiseRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SetUserExpertiseRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetUserExpertiseRequest) GetExpertise() []string {
+	if x != nil {
+		return x.Expertise
+	}
+	return nil
+}
+
+type SetUserMaxOpenReviewsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Не задан - снять ограничение
+	MaxOpenReviews *int32 `protobuf:"varint,2,opt,name=max_open_reviews,json=maxOpenReviews,proto3,oneof" json:"max_open_reviews,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SetUserMaxOpenReviewsRequest) Reset() {
+	*x = SetUserMaxOpenReviewsRequest{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserMaxOpenReviewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserMaxOpenReviewsRequest) ProtoMessage() {}
+
+func (x *SetUserMaxOpenReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserMaxOpenReviewsRequest.ProtoReflect.Descriptor instead.
+func (*SetUserMaxOpenReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SetUserMaxOpenReviewsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetUserMaxOpenReviewsRequest) GetMaxOpenReviews() int32 {
+	if x != nil && x.MaxOpenReviews != nil {
+		return *x.MaxOpenReviews
+	}
+	return 0
+}
+
+type SetUserReviewWeightRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ReviewWeight  float64                `protobuf:"fixed64,2,opt,name=review_weight,json=reviewWeight,proto3" json:"review_weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserReviewWeightRequest) Reset() {
+	*x = SetUserReviewWeightRequest{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserReviewWeightRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserReviewWeightRequest) ProtoMessage() {}
+
+func (x *SetUserReviewWeightRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserReviewWeightRequest.ProtoReflect.Descriptor instead.
+func (*SetUserReviewWeightRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SetUserReviewWeightRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetUserReviewWeightRequest) GetReviewWeight() float64 {
+	if x != nil {
+		return x.ReviewWeight
+	}
+	return 0
+}
+
+type SetUserTraineeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IsTrainee     bool                   `protobuf:"varint,2,opt,name=is_trainee,json=isTrainee,proto3" json:"is_trainee,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserTraineeRequest) Reset() {
+	*x = SetUserTraineeRequest{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserTraineeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserTraineeRequest) ProtoMessage() {}
+
+func (x *SetUserTraineeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserTraineeRequest.ProtoReflect.Descriptor instead.
+func (*SetUserTraineeRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetUserTraineeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetUserTraineeRequest) GetIsTrainee() bool {
+	if x != nil {
+		return x.IsTrainee
+	}
+	return false
+}
+
+type UserReview struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId        string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Status          string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Repository      string                 `protobuf:"bytes,5,opt,name=repository,proto3" json:"repository,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	AssignedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
+	MergedAt        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=merged_at,json=mergedAt,proto3" json:"merged_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UserReview) Reset() {
+	*x = UserReview{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserReview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserReview) ProtoMessage() {}
+
+func (x *UserReview) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserReview.ProtoReflect.Descriptor instead.
+func (*UserReview) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UserReview) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *UserReview) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *UserReview) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *UserReview) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UserReview) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *UserReview) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *UserReview) GetAssignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AssignedAt
+	}
+	return nil
+}
+
+func (x *UserReview) GetMergedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MergedAt
+	}
+	return nil
+}
+
+type GetUserReviewsRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	UserId     string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status     string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	AuthorId   string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Repository string                 `protobuf:"bytes,4,opt,name=repository,proto3" json:"repository,omitempty"`
+	From       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=from,proto3" json:"from,omitempty"`
+	To         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=to,proto3" json:"to,omitempty"`
+	// created или assigned
+	SortBy        string `protobuf:"bytes,7,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	Ascending     bool   `protobuf:"varint,8,opt,name=ascending,proto3" json:"ascending,omitempty"`
+	Limit         int32  `protobuf:"varint,9,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string `protobuf:"bytes,10,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserReviewsRequest) Reset() {
+	*x = GetUserReviewsRequest{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserReviewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserReviewsRequest) ProtoMessage() {}
+
+func (x *GetUserReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserReviewsRequest.ProtoReflect.Descriptor instead.
+func (*GetUserReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetUserReviewsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetUserReviewsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetUserReviewsRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *GetUserReviewsRequest) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *GetUserReviewsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetUserReviewsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetUserReviewsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *GetUserReviewsRequest) GetAscending() bool {
+	if x != nil {
+		return x.Ascending
+	}
+	return false
+}
+
+func (x *GetUserReviewsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetUserReviewsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type GetUserReviewsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequests  []*UserReview          `protobuf:"bytes,1,rep,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserReviewsResponse) Reset() {
+	*x = GetUserReviewsResponse{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserReviewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserReviewsResponse) ProtoMessage() {}
+
+func (x *GetUserReviewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserReviewsResponse.ProtoReflect.Descriptor instead.
+func (*GetUserReviewsResponse) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetUserReviewsResponse) GetPullRequests() []*UserReview {
+	if x != nil {
+		return x.PullRequests
+	}
+	return nil
+}
+
+func (x *GetUserReviewsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type CreatePullRequestRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId        string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Repository      string                 `protobuf:"bytes,4,opt,name=repository,proto3" json:"repository,omitempty"`
+	Number          int32                  `protobuf:"varint,5,opt,name=number,proto3" json:"number,omitempty"`
+	Files           []string               `protobuf:"bytes,6,rep,name=files,proto3" json:"files,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreatePullRequestRequest) Reset() {
+	*x = CreatePullRequestRequest{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePullRequestRequest) ProtoMessage() {}
+
+func (x *CreatePullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePullRequestRequest.ProtoReflect.Descriptor instead.
+func (*CreatePullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CreatePullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *CreatePullRequestRequest) GetFiles() []string {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type GetPullRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPullRequestRequest) Reset() {
+	*x = GetPullRequestRequest{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPullRequestRequest) ProtoMessage() {}
+
+func (x *GetPullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPullRequestRequest.ProtoReflect.Descriptor instead.
+func (*GetPullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetPullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+type ListPullRequestsRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Status      string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	AuthorId    string                 `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	TeamName    string                 `protobuf:"bytes,3,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	ReviewerId  string                 `protobuf:"bytes,4,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	Repository  string                 `protobuf:"bytes,5,opt,name=repository,proto3" json:"repository,omitempty"`
+	CreatedFrom *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	MergedFrom  *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=merged_from,json=mergedFrom,proto3" json:"merged_from,omitempty"`
+	MergedTo    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=merged_to,json=mergedTo,proto3" json:"merged_to,omitempty"`
+	// Полнотекстовый поиск по названию
+	Query string `protobuf:"bytes,10,opt,name=query,proto3" json:"query,omitempty"`
+	// created, merged или relevance
+	SortBy        string `protobuf:"bytes,11,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	Ascending     bool   `protobuf:"varint,12,opt,name=ascending,proto3" json:"ascending,omitempty"`
+	Limit         int32  `protobuf:"varint,13,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string `protobuf:"bytes,14,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPullRequestsRequest) Reset() {
+	*x = ListPullRequestsRequest{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPullRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPullRequestsRequest) ProtoMessage() {}
+
+func (x *ListPullRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPullRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListPullRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListPullRequestsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListPullRequestsRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListPullRequestsRequest) GetMergedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MergedFrom
+	}
+	return nil
+}
+
+func (x *ListPullRequestsRequest) GetMergedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MergedTo
+	}
+	return nil
+}
+
+func (x *ListPullRequestsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetAscending() bool {
+	if x != nil {
+		return x.Ascending
+	}
+	return false
+}
+
+func (x *ListPullRequestsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPullRequestsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListPullRequestsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequests  []*PullRequest         `protobuf:"bytes,1,rep,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPullRequestsResponse) Reset() {
+	*x = ListPullRequestsResponse{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPullRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPullRequestsResponse) ProtoMessage() {}
+
+func (x *ListPullRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPullRequestsResponse.ProtoReflect.Descriptor instead.
+func (*ListPullRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListPullRequestsResponse) GetPullRequests() []*PullRequest {
+	if x != nil {
+		return x.PullRequests
+	}
+	return nil
+}
+
+func (x *ListPullRequestsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type MergePullRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergePullRequestRequest) Reset() {
+	*x = MergePullRequestRequest{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergePullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergePullRequestRequest) ProtoMessage() {}
+
+func (x *MergePullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergePullRequestRequest.ProtoReflect.Descriptor instead.
+func (*MergePullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *MergePullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+type ReassignReviewerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	OldUserId     string                 `protobuf:"bytes,2,opt,name=old_user_id,json=oldUserId,proto3" json:"old_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReassignReviewerRequest) Reset() {
+	*x = ReassignReviewerRequest{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignReviewerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignReviewerRequest) ProtoMessage() {}
+
+func (x *ReassignReviewerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignReviewerRequest.ProtoReflect.Descriptor instead.
+func (*ReassignReviewerRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ReassignReviewerRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *ReassignReviewerRequest) GetOldUserId() string {
+	if x != nil {
+		return x.OldUserId
+	}
+	return ""
+}
+
+type ReassignReviewerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequest   *PullRequest           `protobuf:"bytes,1,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`
+	ReplacedBy    string                 `protobuf:"bytes,2,opt,name=replaced_by,json=replacedBy,proto3" json:"replaced_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReassignReviewerResponse) Reset() {
+	*x = ReassignReviewerResponse{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignReviewerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignReviewerResponse) ProtoMessage() {}
+
+func (x *ReassignReviewerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignReviewerResponse.ProtoReflect.Descriptor instead.
+func (*ReassignReviewerResponse) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ReassignReviewerResponse) GetPullRequest() *PullRequest {
+	if x != nil {
+		return x.PullRequest
+	}
+	return nil
+}
+
+func (x *ReassignReviewerResponse) GetReplacedBy() string {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return ""
+}
+
+type GetUnderstaffedPullRequestsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUnderstaffedPullRequestsRequest) Reset() {
+	*x = GetUnderstaffedPullRequestsRequest{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnderstaffedPullRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnderstaffedPullRequestsRequest) ProtoMessage() {}
+
+func (x *GetUnderstaffedPullRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnderstaffedPullRequestsRequest.ProtoReflect.Descriptor instead.
+func (*GetUnderstaffedPullRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{22}
+}
+
+type UnderstaffedPullRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId        string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Status          string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	DesiredCount    int32                  `protobuf:"varint,5,opt,name=desired_count,json=desiredCount,proto3" json:"desired_count,omitempty"`
+	AssignedCount   int32                  `protobuf:"varint,6,opt,name=assigned_count,json=assignedCount,proto3" json:"assigned_count,omitempty"`
+	QueuedAt        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=queued_at,json=queuedAt,proto3" json:"queued_at,omitempty"`
+	Attempts        int32                  `protobuf:"varint,8,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastAttemptAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_attempt_at,json=lastAttemptAt,proto3" json:"last_attempt_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UnderstaffedPullRequest) Reset() {
+	*x = UnderstaffedPullRequest{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnderstaffedPullRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnderstaffedPullRequest) ProtoMessage() {}
+
+func (x *UnderstaffedPullRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnderstaffedPullRequest.ProtoReflect.Descriptor instead.
+func (*UnderstaffedPullRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UnderstaffedPullRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *UnderstaffedPullRequest) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *UnderstaffedPullRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *UnderstaffedPullRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UnderstaffedPullRequest) GetDesiredCount() int32 {
+	if x != nil {
+		return x.DesiredCount
+	}
+	return 0
+}
+
+func (x *UnderstaffedPullRequest) GetAssignedCount() int32 {
+	if x != nil {
+		return x.AssignedCount
+	}
+	return 0
+}
+
+func (x *UnderstaffedPullRequest) GetQueuedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.QueuedAt
+	}
+	return nil
+}
+
+func (x *UnderstaffedPullRequest) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *UnderstaffedPullRequest) GetLastAttemptAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastAttemptAt
+	}
+	return nil
+}
+
+type GetUnderstaffedPullRequestsResponse struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	PullRequests  []*UnderstaffedPullRequest `protobuf:"bytes,1,rep,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUnderstaffedPullRequestsResponse) Reset() {
+	*x = GetUnderstaffedPullRequestsResponse{}
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnderstaffedPullRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnderstaffedPullRequestsResponse) ProtoMessage() {}
+
+func (x *GetUnderstaffedPullRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_prmanager_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnderstaffedPullRequestsResponse.ProtoReflect.Descriptor instead.
+func (*GetUnderstaffedPullRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_prmanager_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetUnderstaffedPullRequestsResponse) GetPullRequests() []*UnderstaffedPullRequest {
+	if x != nil {
+		return x.PullRequests
+	}
+	return nil
+}
+
+var File_prmanager_v1_prmanager_proto protoreflect.FileDescriptor
+
+const file_prmanager_v1_prmanager_proto_rawDesc = "" +
+	"\n" +
+	"\x1cprmanager/v1/prmanager.proto\x12\fprmanager.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9b\x02\n" +
+	"\x04User\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\tteam_name\x18\x03 \x01(\tR\bteamName\x12\x1b\n" +
+	"\tis_active\x18\x04 \x01(\bR\bisActive\x12-\n" +
+	"\x10max_open_reviews\x18\x05 \x01(\x05H\x00R\x0emaxOpenReviews\x88\x01\x01\x12#\n" +
+	"\rreview_weight\x18\x06 \x01(\x01R\freviewWeight\x12\x1d\n" +
+	"\n" +
+	"is_trainee\x18\a \x01(\bR\tisTrainee\x12\x1c\n" +
+	"\texpertise\x18\b \x03(\tR\texpertiseB\x13\n" +
+	"\x11_max_open_reviews\"\x82\x01\n" +
+	"\x04Team\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12,\n" +
+	"\amembers\x18\x02 \x03(\v2\x12.prmanager.v1.UserR\amembers\x12/\n" +
+	"\x13assignment_strategy\x18\x03 \x01(\tR\x12assignmentStrategy\"F\n" +
+	"\x0eShadowReviewer\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tmentor_id\x18\x02 \x01(\tR\bmentorId\"\xa1\x04\n" +
+	"\vPullRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x05 \x01(\tR\n" +
+	"repository\x12\x16\n" +
+	"\x06number\x18\x06 \x01(\x05R\x06number\x12-\n" +
+	"\x12assigned_reviewers\x18\a \x03(\tR\x11assignedReviewers\x12G\n" +
+	"\x10shadow_reviewers\x18\b \x03(\v2\x1c.prmanager.v1.ShadowReviewerR\x0fshadowReviewers\x12\x14\n" +
+	"\x05files\x18\t \x03(\tR\x05files\x12-\n" +
+	"\x12pending_assignment\x18\n" +
+	" \x01(\bR\x11pendingAssignment\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x127\n" +
+	"\tmerged_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\bmergedAt\x12\x17\n" +
+	"\x04rank\x18\r \x01(\x01H\x00R\x04rank\x88\x01\x01B\a\n" +
+	"\x05_rank\";\n" +
+	"\x11CreateTeamRequest\x12&\n" +
+	"\x04team\x18\x01 \x01(\v2\x12.prmanager.v1.TeamR\x04team\"-\n" +
+	"\x0eGetTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\"f\n" +
+	"\x16SetTeamStrategyRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12/\n" +
+	"\x13assignment_strategy\x18\x02 \x01(\tR\x12assignmentStrategy\"L\n" +
+	"\x14SetUserActiveRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tis_active\x18\x02 \x01(\bR\bisActive\"P\n" +
+	"\x17SetUserExpertiseRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1c\n" +
+	"\texpertise\x18\x02 \x03(\tR\texpertise\"{\n" +
+	"\x1cSetUserMaxOpenReviewsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12-\n" +
+	"\x10max_open_reviews\x18\x02 \x01(\x05H\x00R\x0emaxOpenReviews\x88\x01\x01B\x13\n" +
+	"\x11_max_open_reviews\"Z\n" +
+	"\x1aSetUserReviewWeightRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\rreview_weight\x18\x02 \x01(\x01R\freviewWeight\"O\n" +
+	"\x15SetUserTraineeRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"is_trainee\x18\x02 \x01(\bR\tisTrainee\"\xe6\x02\n" +
+	"\n" +
+	"UserReview\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x05 \x01(\tR\n" +
+	"repository\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vassigned_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"assignedAt\x127\n" +
+	"\tmerged_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bmergedAt\"\xc6\x02\n" +
+	"\x15GetUserReviewsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x04 \x01(\tR\n" +
+	"repository\x12.\n" +
+	"\x04from\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x17\n" +
+	"\asort_by\x18\a \x01(\tR\x06sortBy\x12\x1c\n" +
+	"\tascending\x18\b \x01(\bR\tascending\x12\x14\n" +
+	"\x05limit\x18\t \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\n" +
+	" \x01(\tR\x06cursor\"x\n" +
+	"\x16GetUserReviewsResponse\x12=\n" +
+	"\rpull_requests\x18\x01 \x03(\v2\x18.prmanager.v1.UserReviewR\fpullRequests\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\xd9\x01\n" +
+	"\x18CreatePullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x04 \x01(\tR\n" +
+	"repository\x12\x16\n" +
+	"\x06number\x18\x05 \x01(\x05R\x06number\x12\x14\n" +
+	"\x05files\x18\x06 \x03(\tR\x05files\"?\n" +
+	"\x15GetPullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\"\x97\x04\n" +
+	"\x17ListPullRequestsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x1b\n" +
+	"\tauthor_id\x18\x02 \x01(\tR\bauthorId\x12\x1b\n" +
+	"\tteam_name\x18\x03 \x01(\tR\bteamName\x12\x1f\n" +
+	"\vreviewer_id\x18\x04 \x01(\tR\n" +
+	"reviewerId\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x05 \x01(\tR\n" +
+	"repository\x12=\n" +
+	"\fcreated_from\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12;\n" +
+	"\vmerged_from\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"mergedFrom\x127\n" +
+	"\tmerged_to\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\bmergedTo\x12\x14\n" +
+	"\x05query\x18\n" +
+	" \x01(\tR\x05query\x12\x17\n" +
+	"\asort_by\x18\v \x01(\tR\x06sortBy\x12\x1c\n" +
+	"\tascending\x18\f \x01(\bR\tascending\x12\x14\n" +
+	"\x05limit\x18\r \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x0e \x01(\tR\x06cursor\"{\n" +
+	"\x18ListPullRequestsResponse\x12>\n" +
+	"\rpull_requests\x18\x01 \x03(\v2\x19.prmanager.v1.PullRequestR\fpullRequests\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"A\n" +
+	"\x17MergePullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\"a\n" +
+	"\x17ReassignReviewerRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12\x1e\n" +
+	"\vold_user_id\x18\x02 \x01(\tR\toldUserId\"y\n" +
+	"\x18ReassignReviewerResponse\x12<\n" +
+	"\fpull_request\x18\x01 \x01(\v2\x19.prmanager.v1.PullRequestR\vpullRequest\x12\x1f\n" +
+	"\vreplaced_by\x18\x02 \x01(\tR\n" +
+	"replacedBy\"$\n" +
+	"\"GetUnderstaffedPullRequestsRequest\"\x87\x03\n" +
+	"\x17UnderstaffedPullRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12#\n" +
+	"\rdesired_count\x18\x05 \x01(\x05R\fdesiredCount\x12%\n" +
+	"\x0eassigned_count\x18\x06 \x01(\x05R\rassignedCount\x127\n" +
+	"\tqueued_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bqueuedAt\x12\x1a\n" +
+	"\battempts\x18\b \x01(\x05R\battempts\x12B\n" +
+	"\x0flast_attempt_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\rlastAttemptAt\"q\n" +
+	"#GetUnderstaffedPullRequestsResponse\x12J\n" +
+	"\rpull_requests\x18\x01 \x03(\v2%.prmanager.v1.UnderstaffedPullRequestR\fpullRequests2\x91\n" +
+	"\n" +
+	"\tPRManager\x12A\n" +
+	"\n" +
+	"CreateTeam\x12\x1f.prmanager.v1.CreateTeamRequest\x1a\x12.prmanager.v1.Team\x12;\n" +
+	"\aGetTeam\x12\x1c.prmanager.v1.GetTeamRequest\x1a\x12.prmanager.v1.Team\x12K\n" +
+	"\x0fSetTeamStrategy\x12$.prmanager.v1.SetTeamStrategyRequest\x1a\x12.prmanager.v1.Team\x12G\n" +
+	"\rSetUserActive\x12\".prmanager.v1.SetUserActiveRequest\x1a\x12.prmanager.v1.User\x12M\n" +
+	"\x10SetUserExpertise\x12%.prmanager.v1.SetUserExpertiseRequest\x1a\x12.prmanager.v1.User\x12W\n" +
+	"\x15SetUserMaxOpenReviews\x12*.prmanager.v1.SetUserMaxOpenReviewsRequest\x1a\x12.prmanager.v1.User\x12S\n" +
+	"\x13SetUserReviewWeight\x12(.prmanager.v1.SetUserReviewWeightRequest\x1a\x12.prmanager.v1.User\x12I\n" +
+	"\x0eSetUserTrainee\x12#.prmanager.v1.SetUserTraineeRequest\x1a\x12.prmanager.v1.User\x12[\n" +
+	"\x0eGetUserReviews\x12#.prmanager.v1.GetUserReviewsRequest\x1a$.prmanager.v1.GetUserReviewsResponse\x12V\n" +
+	"\x11CreatePullRequest\x12&.prmanager.v1.CreatePullRequestRequest\x1a\x19.prmanager.v1.PullRequest\x12P\n" +
+	"\x0eGetPullRequest\x12#.prmanager.v1.GetPullRequestRequest\x1a\x19.prmanager.v1.PullRequest\x12a\n" +
+	"\x10ListPullRequests\x12%.prmanager.v1.ListPullRequestsRequest\x1a&.prmanager.v1.ListPullRequestsResponse\x12T\n" +
+	"\x10MergePullRequest\x12%.prmanager.v1.MergePullRequestRequest\x1a\x19.prmanager.v1.PullRequest\x12a\n" +
+	"\x10ReassignReviewer\x12%.prmanager.v1.ReassignReviewerRequest\x1a&.prmanager.v1.ReassignReviewerResponse\x12\x82\x01\n" +
+	"\x1bGetUnderstaffedPullRequests\x120.prmanager.v1.GetUnderstaffedPullRequestsRequest\x1a1.prmanager.v1.GetUnderstaffedPullRequestsResponseB&Z$prmanager/internal/grpc_server/pb;pbb\x06proto3"
+
+var (
+	file_prmanager_v1_prmanager_proto_rawDescOnce sync.Once
+	file_prmanager_v1_prmanager_proto_rawDescData []byte
+)
+
+func file_prmanager_v1_prmanager_proto_rawDescGZIP() []byte {
+	file_prmanager_v1_prmanager_proto_rawDescOnce.Do(func() {
+		file_prmanager_v1_prmanager_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_prmanager_v1_prmanager_proto_rawDesc), len(file_prmanager_v1_prmanager_proto_rawDesc)))
+	})
+	return file_prmanager_v1_prmanager_proto_rawDescData
+}
+
+var file_prmanager_v1_prmanager_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_prmanager_v1_prmanager_proto_goTypes = []any{
+	(*User)(nil),                                // 0: prmanager.v1.User
+	(*Team)(nil),                                // 1: prmanager.v1.Team
+	(*ShadowReviewer)(nil),                      // 2: prmanager.v1.ShadowReviewer
+	(*PullRequest)(nil),                         // 3: prmanager.v1.PullRequest
+	(*CreateTeamRequest)(nil),                   // 4: prmanager.v1.CreateTeamRequest
+	(*GetTeamRequest)(nil),                      // 5: prmanager.v1.GetTeamRequest
+	(*SetTeamStrategyRequest)(nil),              // 6: prmanager.v1.SetTeamStrategyRequest
+	(*SetUserActiveRequest)(nil),                // 7: prmanager.v1.SetUserActiveRequest
+	(*SetUserExpertiseRequest)(nil),             // 8: prmanager.v1.SetUserExpertiseRequest
+	(*SetUserMaxOpenReviewsRequest)(nil),        // 9: prmanager.v1.SetUserMaxOpenReviewsRequest
+	(*SetUserReviewWeightRequest)(nil),          // 10: prmanager.v1.SetUserReviewWeightRequest
+	(*SetUserTraineeRequest)(nil),               // 11: prmanager.v1.SetUserTraineeRequest
+	(*UserReview)(nil),                          // 12: prmanager.v1.UserReview
+	(*GetUserReviewsRequest)(nil),               // 13: prmanager.v1.GetUserReviewsRequest
+	(*GetUserReviewsResponse)(nil),              // 14: prmanager.v1.GetUserReviewsResponse
+	(*CreatePullRequestRequest)(nil),            // 15: prmanager.v1.CreatePullRequestRequest
+	(*GetPullRequestRequest)(nil),               // 16: prmanager.v1.GetPullRequestRequest
+	(*ListPullRequestsRequest)(nil),             // 17: prmanager.v1.ListPullRequestsRequest
+	(*ListPullRequestsResponse)(nil),            // 18: prmanager.v1.ListPullRequestsResponse
+	(*MergePullRequestRequest)(nil),             // 19: prmanager.v1.MergePullRequestRequest
+	(*ReassignReviewerRequest)(nil),             // 20: prmanager.v1.ReassignReviewerRequest
+	(*ReassignReviewerResponse)(nil),            // 21: prmanager.v1.ReassignReviewerResponse
+	(*GetUnderstaffedPullRequestsRequest)(nil),  // 22: prmanager.v1.GetUnderstaffedPullRequestsRequest
+	(*UnderstaffedPullRequest)(nil),             // 23: prmanager.v1.UnderstaffedPullRequest
+	(*GetUnderstaffedPullRequestsResponse)(nil), // 24: prmanager.v1.GetUnderstaffedPullRequestsResponse
+	(*timestamppb.Timestamp)(nil),               // 25: google.protobuf.Timestamp
+}
+var file_prmanager_v1_prmanager_proto_depIdxs = []int32{
+	0,  // 0: prmanager.v1.Team.members:type_name -> prmanager.v1.User
+	2,  // 1: prmanager.v1.PullRequest.shadow_reviewers:type_name -> prmanager.v1.ShadowReviewer
+	25, // 2: prmanager.v1.PullRequest.created_at:type_name -> google.protobuf.Timestamp
+	25, // 3: prmanager.v1.PullRequest.merged_at:type_name -> google.protobuf.Timestamp
+	1,  // 4: prmanager.v1.CreateTeamRequest.team:type_name -> prmanager.v1.Team
+	25, // 5: prmanager.v1.UserReview.created_at:type_name -> google.protobuf.Timestamp
+	25, // 6: prmanager.v1.UserReview.assigned_at:type_name -> google.protobuf.Timestamp
+	25, // 7: prmanager.v1.UserReview.merged_at:type_name -> google.protobuf.Timestamp
+	25, // 8: prmanager.v1.GetUserReviewsRequest.from:type_name -> google.protobuf.Timestamp
+	25, // 9: prmanager.v1.GetUserReviewsRequest.to:type_name -> google.protobuf.Timestamp
+	12, // 10: prmanager.v1.GetUserReviewsResponse.pull_requests:type_name -> prmanager.v1.UserReview
+	25, // 11: prmanager.v1.ListPullRequestsRequest.created_from:type_name -> google.protobuf.Timestamp
+	25, // 12: prmanager.v1.ListPullRequestsRequest.created_to:type_name -> google.protobuf.Timestamp
+	25, // 13: prmanager.v1.ListPullRequestsRequest.merged_from:type_name -> google.protobuf.Timestamp
+	25, // 14: prmanager.v1.ListPullRequestsRequest.merged_to:type_name -> google.protobuf.Timestamp
+	3,  // 15: prmanager.v1.ListPullRequestsResponse.pull_requests:type_name -> prmanager.v1.PullRequest
+	3,  // 16: prmanager.v1.ReassignReviewerResponse.pull_request:type_name -> prmanager.v1.PullRequest
+	25, // 17: prmanager.v1.UnderstaffedPullRequest.queued_at:type_name -> google.protobuf.Timestamp
+	25, // 18: prmanager.v1.UnderstaffedPullRequest.last_attempt_at:type_name -> google.protobuf.Timestamp
+	23, // 19: prmanager.v1.GetUnderstaffedPullRequestsResponse.pull_requests:type_name -> prmanager.v1.UnderstaffedPullRequest
+	4,  // 20: prmanager.v1.PRManager.CreateTeam:input_type -> prmanager.v1.CreateTeamRequest
+	5,  // 21: prmanager.v1.PRManager.GetTeam:input_type -> prmanager.v1.GetTeamRequest
+	6,  // 22: prmanager.v1.PRManager.SetTeamStrategy:input_type -> prmanager.v1.SetTeamStrategyRequest
+	7,  // 23: prmanager.v1.PRManager.SetUserActive:input_type -> prmanager.v1.SetUserActiveRequest
+	8,  // 24: prmanager.v1.PRManager.SetUserExpertise:input_type -> prmanager.v1.SetUserExpertiseRequest
+	9,  // 25: prmanager.v1.PRManager.SetUserMaxOpenReviews:input_type -> prmanager.v1.SetUserMaxOpenReviewsRequest
+	10, // 26: prmanager.v1.PRManager.SetUserReviewWeight:input_type -> prmanager.v1.SetUserReviewWeightRequest
+	11, // 27: prmanager.v1.PRManager.SetUserTrainee:input_type -> prmanager.v1.SetUserTraineeRequest
+	13, // 28: prmanager.v1.PRManager.GetUserReviews:input_type -> prmanager.v1.GetUserReviewsRequest
+	15, // 29: prmanager.v1.PRManager.CreatePullRequest:input_type -> prmanager.v1.CreatePullRequestRequest
+	16, // 30: prmanager.v1.PRManager.GetPullRequest:input_type -> prmanager.v1.GetPullRequestRequest
+	17, // 31: prmanager.v1.PRManager.ListPullRequests:input_type -> prmanager.v1.ListPullRequestsRequest
+	19, // 32: prmanager.v1.PRManager.MergePullRequest:input_type -> prmanager.v1.MergePullRequestRequest
+	20, // 33: prmanager.v1.PRManager.ReassignReviewer:input_type -> prmanager.v1.ReassignReviewerRequest
+	22, // 34: prmanager.v1.PRManager.GetUnderstaffedPullRequests:input_type -> prmanager.v1.GetUnderstaffedPullRequestsRequest
+	1,  // 35: prmanager.v1.PRManager.CreateTeam:output_type -> prmanager.v1.Team
+	1,  // 36: prmanager.v1.PRManager.GetTeam:output_type -> prmanager.v1.Team
+	1,  // 37: prmanager.v1.PRManager.SetTeamStrategy:output_type -> prmanager.v1.Team
+	0,  // 38: prmanager.v1.PRManager.SetUserActive:output_type -> prmanager.v1.User
+	0,  // 39: prmanager.v1.PRManager.SetUserExpertise:output_type -> prmanager.v1.User
+	0,  // 40: prmanager.v1.PRManager.SetUserMaxOpenReviews:output_type -> prmanager.v1.User
+	0,  // 41: prmanager.v1.PRManager.SetUserReviewWeight:output_type -> prmanager.v1.User
+	0,  // 42: prmanager.v1.PRManager.SetUserTrainee:output_type -> prmanager.v1.User
+	14, // 43: prmanager.v1.PRManager.GetUserReviews:output_type -> prmanager.v1.GetUserReviewsResponse
+	3,  // 44: prmanager.v1.PRManager.CreatePullRequest:output_type -> prmanager.v1.PullRequest
+	3,  // 45: prmanager.v1.PRManager.GetPullRequest:output_type -> prmanager.v1.PullRequest
+	18, // 46: prmanager.v1.PRManager.ListPullRequests:output_type -> prmanager.v1.ListPullRequestsResponse
+	3,  // 47: prmanager.v1.PRManager.MergePullRequest:output_type -> prmanager.v1.PullRequest
+	21, // 48: prmanager.v1.PRManager.ReassignReviewer:output_type -> prmanager.v1.ReassignReviewerResponse
+	24, // 49: prmanager.v1.PRManager.GetUnderstaffedPullRequests:output_type -> prmanager.v1.GetUnderstaffedPullRequestsResponse
+	35, // [35:50] is the sub-list for method output_type
+	20, // [20:35] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_prmanager_v1_prmanager_proto_init() }
+func file_prmanager_v1_prmanager_proto_init() {
+	if File_prmanager_v1_prmanager_proto != nil {
+		return
+	}
+	file_prmanager_v1_prmanager_proto_msgTypes[0].OneofWrappers = []any{}
+	file_prmanager_v1_prmanager_proto_msgTypes[3].OneofWrappers = []any{}
+	file_prmanager_v1_prmanager_proto_msgTypes[9].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_prmanager_v1_prmanager_proto_rawDesc), len(file_prmanager_v1_prmanager_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   25,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_prmanager_v1_prmanager_proto_goTypes,
+		DependencyIndexes: file_prmanager_v1_prmanager_proto_depIdxs,
+		MessageInfos:      file_prmanager_v1_prmanager_proto_msgTypes,
+	}.Build()
+	File_prmanager_v1_prmanager_proto = out.File
+	file_prmanager_v1_prmanager_proto_goTypes = nil
+	file_prmanager_v1_prmanager_proto_depIdxs = nil
+}
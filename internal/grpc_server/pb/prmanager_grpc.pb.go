@@ -0,0 +1,662 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v5.29.3
+// source: prmanager/v1/prmanager.proto
+
+// API сервиса назначения ревьюеров для внутренних инструментов.
+// Повторяет HTTP-маршруты: команды, пользователи, PR и переназначение.
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PRManager_CreateTeam_FullMethodName                  = "/prmanager.v1.PRManager/CreateTeam"
+	PRManager_GetTeam_FullMethodName                     = "/prmanager.v1.PRManager/GetTeam"
+	PRManager_SetTeamStrategy_FullMethodName             = "/prmanager.v1.PRManager/SetTeamStrategy"
+	PRManager_SetUserActive_FullMethodName               = "/prmanager.v1.PRManager/SetUserActive"
+	PRManager_SetUserExpertise_FullMethodName            = "/prmanager.v1.PRManager/SetUserExpertise"
+	PRManager_SetUserMaxOpenReviews_FullMethodName       = "/prmanager.v1.PRManager/SetUserMaxOpenReviews"
+	PRManager_SetUserReviewWeight_FullMethodName         = "/prmanager.v1.PRManager/SetUserReviewWeight"
+	PRManager_SetUserTrainee_FullMethodName              = "/prmanager.v1.PRManager/SetUserTrainee"
+	PRManager_GetUserReviews_FullMethodName              = "/prmanager.v1.PRManager/GetUserReviews"
+	PRManager_CreatePullRequest_FullMethodName           = "/prmanager.v1.PRManager/CreatePullRequest"
+	PRManager_GetPullRequest_FullMethodName              = "/prmanager.v1.PRManager/GetPullRequest"
+	PRManager_ListPullRequests_FullMethodName            = "/prmanager.v1.PRManager/ListPullRequests"
+	PRManager_MergePullRequest_FullMethodName            = "/prmanager.v1.PRManager/MergePullRequest"
+	PRManager_ReassignReviewer_FullMethodName            = "/prmanager.v1.PRManager/ReassignReviewer"
+	PRManager_GetUnderstaffedPullRequests_FullMethodName = "/prmanager.v1.PRManager/GetUnderstaffedPullRequests"
+)
+
+// PRManagerClient is the client API for PRManager service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PRManagerClient interface {
+	// Teams
+	CreateTeam(ctx context.Context, in *CreateTeamRequest, opts ...grpc.CallOption) (*Team, error)
+	GetTeam(ctx context.Context, in *GetTeamRequest, opts ...grpc.CallOption) (*Team, error)
+	SetTeamStrategy(ctx context.Context, in *SetTeamStrategyRequest, opts ...grpc.CallOption) (*Team, error)
+	// Users
+	SetUserActive(ctx context.Context, in *SetUserActiveRequest, opts ...grpc.CallOption) (*User, error)
+	SetUserExpertise(ctx context.Context, in *SetUserExpertiseRequest, opts ...grpc.CallOption) (*User, error)
+	SetUserMaxOpenReviews(ctx context.Context, in *SetUserMaxOpenReviewsRequest, opts ...grpc.CallOption) (*User, error)
+	SetUserReviewWeight(ctx context.Context, in *SetUserReviewWeightRequest, opts ...grpc.CallOption) (*User, error)
+	SetUserTrainee(ctx context.Context, in *SetUserTraineeRequest, opts ...grpc.CallOption) (*User, error)
+	GetUserReviews(ctx context.Context, in *GetUserReviewsRequest, opts ...grpc.CallOption) (*GetUserReviewsResponse, error)
+	// Pull Requests
+	CreatePullRequest(ctx context.Context, in *CreatePullRequestRequest, opts ...grpc.CallOption) (*PullRequest, error)
+	GetPullRequest(ctx context.Context, in *GetPullRequestRequest, opts ...grpc.CallOption) (*PullRequest, error)
+	ListPullRequests(ctx context.Context, in *ListPullRequestsRequest, opts ...grpc.CallOption) (*ListPullRequestsResponse, error)
+	MergePullRequest(ctx context.Context, in *MergePullRequestRequest, opts ...grpc.CallOption) (*PullRequest, error)
+	ReassignReviewer(ctx context.Context, in *ReassignReviewerRequest, opts ...grpc.CallOption) (*ReassignReviewerResponse, error)
+	GetUnderstaffedPullRequests(ctx context.Context, in *GetUnderstaffedPullRequestsRequest, opts ...grpc.CallOption) (*GetUnderstaffedPullRequestsResponse, error)
+}
+
+type pRManagerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPRManagerClient(cc grpc.ClientConnInterface) PRManagerClient {
+	return &pRManagerClient{cc}
+}
+
+func (c *pRManagerClient) CreateTeam(ctx context.Context, in *CreateTeamRequest, opts ...grpc.CallOption) (*Team, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Team)
+	err := c.cc.Invoke(ctx, PRManager_CreateTeam_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pRManagerClient) GetTeam(ctx context.Context, in *GetTeamRequest, opts ...grpc.CallOption) (*Team, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Team)
+	err := c.cc.Invoke(ctx, PRManager_GetTeam_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pRManagerClient) SetTeamStrategy(ctx context.Context, in *SetTeamStrategyRequest, opts ...grpc.CallOption) (*Team, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Team)
+	err := c.cc.Invoke(ctx, PRManager_SetTeamStrategy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pRManagerClient) SetUserActive(ctx context.Context, in *SetUserActiveRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, PRManager_SetUserActive_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pRManagerClient) SetUserExpertise(ctx context.Context, in *SetUserExpertiseRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, PRManager_SetUserExpertise_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pRManagerClient) SetUserMaxOpenReviews(ctx context.Context, in *SetUserMaxOpenReviewsRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, PRManager_SetUserMaxOpenReviews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pRManagerClient) SetUserReviewWeight(ctx context.Context, in *SetUserReviewWeightRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, PRManager_SetUserReviewWeight_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pRManagerClient) SetUserTrainee(ctx context.Context, in *SetUserTraineeRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, PRManager_SetUserTrainee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pRManagerClient) GetUserReviews(ctx context.Context, in *GetUserReviewsRequest, opts ...grpc.CallOption) (*GetUserReviewsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserReviewsResponse)
+	err := c.cc.Invoke(ctx, PRManager_GetUserReviews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pRManagerClient) CreatePullRequest(ctx context.Context, in *CreatePullRequestRequest, opts ...grpc.CallOption) (*PullRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PullRequest)
+	err := c.cc.Invoke(ctx, PRManager_CreatePullRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pRManagerClient) GetPullRequest(ctx context.Context, in *GetPullRequestRequest, opts ...grpc.CallOption) (*PullRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PullRequest)
+	err := c.cc.Invoke(ctx, PRManager_GetPullRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pRManagerClient) ListPullRequests(ctx context.Context, in *ListPullRequestsRequest, opts ...grpc.CallOption) (*ListPullRequestsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPullRequestsResponse)
+	err := c.cc.Invoke(ctx, PRManager_ListPullRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pRManagerClient) MergePullRequest(ctx context.Context, in *MergePullRequestRequest, opts ...grpc.CallOption) (*PullRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PullRequest)
+	err := c.cc.Invoke(ctx, PRManager_MergePullRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pRManagerClient) ReassignReviewer(ctx context.Context, in *ReassignReviewerRequest, opts ...grpc.CallOption) (*ReassignReviewerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReassignReviewerResponse)
+	err := c.cc.Invoke(ctx, PRManager_ReassignReviewer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pRManagerClient) GetUnderstaffedPullRequests(ctx context.Context, in *GetUnderstaffedPullRequestsRequest, opts ...grpc.CallOption) (*GetUnderstaffedPullRequestsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUnderstaffedPullRequestsResponse)
+	err := c.cc.Invoke(ctx, PRManager_GetUnderstaffedPullRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PRManagerServer is the server API for PRManager service.
+// All implementations must embed UnimplementedPRManagerServer
+// for forward compatibility.
+type PRManagerServer interface {
+	// Teams
+	CreateTeam(context.Context, *CreateTeamRequest) (*Team, error)
+	GetTeam(context.Context, *GetTeamRequest) (*Team, error)
+	SetTeamStrategy(context.Context, *SetTeamStrategyRequest) (*Team, error)
+	// Users
+	SetUserActive(context.Context, *SetUserActiveRequest) (*User, error)
+	SetUserExpertise(context.Context, *SetUserExpertiseRequest) (*User, error)
+	SetUserMaxOpenReviews(context.Context, *SetUserMaxOpenReviewsRequest) (*User, error)
+	SetUserReviewWeight(context.Context, *SetUserReviewWeightRequest) (*User, error)
+	SetUserTrainee(context.Context, *SetUserTraineeRequest) (*User, error)
+	GetUserReviews(context.Context, *GetUserReviewsRequest) (*GetUserReviewsResponse, error)
+	// Pull Requests
+	CreatePullRequest(context.Context, *CreatePullRequestRequest) (*PullRequest, error)
+	GetPullRequest(context.Context, *GetPullRequestRequest) (*PullRequest, error)
+	ListPullRequests(context.Context, *ListPullRequestsRequest) (*ListPullRequestsResponse, error)
+	MergePullRequest(context.Context, *MergePullRequestRequest) (*PullRequest, error)
+	ReassignReviewer(context.Context, *ReassignReviewerRequest) (*ReassignReviewerResponse, error)
+	GetUnderstaffedPullRequests(context.Context, *GetUnderstaffedPullRequestsRequest) (*GetUnderstaffedPullRequestsResponse, error)
+	mustEmbedUnimplementedPRManagerServer()
+}
+
+// UnimplementedPRManagerServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPRManagerServer struct{}
+
+func (UnimplementedPRManagerServer) CreateTeam(context.Context, *CreateTeamRequest) (*Team, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateTeam not implemented")
+}
+func (UnimplementedPRManagerServer) GetTeam(context.Context, *GetTeamRequest) (*Team, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTeam not implemented")
+}
+func (UnimplementedPRManagerServer) SetTeamStrategy(context.Context, *SetTeamStrategyRequest) (*Team, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetTeamStrategy not implemented")
+}
+func (UnimplementedPRManagerServer) SetUserActive(context.Context, *SetUserActiveRequest) (*User, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetUserActive not implemented")
+}
+func (UnimplementedPRManagerServer) SetUserExpertise(context.Context, *SetUserExpertiseRequest) (*User, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetUserExpertise not implemented")
+}
+func (UnimplementedPRManagerServer) SetUserMaxOpenReviews(context.Context, *SetUserMaxOpenReviewsRequest) (*User, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetUserMaxOpenReviews not implemented")
+}
+func (UnimplementedPRManagerServer) SetUserReviewWeight(context.Context, *SetUserReviewWeightRequest) (*User, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetUserReviewWeight not implemented")
+}
+func (UnimplementedPRManagerServer) SetUserTrainee(context.Context, *SetUserTraineeRequest) (*User, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetUserTrainee not implemented")
+}
+func (UnimplementedPRManagerServer) GetUserReviews(context.Context, *GetUserReviewsRequest) (*GetUserReviewsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUserReviews not implemented")
+}
+func (UnimplementedPRManagerServer) CreatePullRequest(context.Context, *CreatePullRequestRequest) (*PullRequest, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreatePullRequest not implemented")
+}
+func (UnimplementedPRManagerServer) GetPullRequest(context.Context, *GetPullRequestRequest) (*PullRequest, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPullRequest not implemented")
+}
+func (UnimplementedPRManagerServer) ListPullRequests(context.Context, *ListPullRequestsRequest) (*ListPullRequestsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPullRequests not implemented")
+}
+func (UnimplementedPRManagerServer) MergePullRequest(context.Context, *MergePullRequestRequest) (*PullRequest, error) {
+	return nil, status.Error(codes.Unimplemented, "method MergePullRequest not implemented")
+}
+func (UnimplementedPRManagerServer) ReassignReviewer(context.Context, *ReassignReviewerRequest) (*ReassignReviewerResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReassignReviewer not implemented")
+}
+func (UnimplementedPRManagerServer) GetUnderstaffedPullRequests(context.Context, *GetUnderstaffedPullRequestsRequest) (*GetUnderstaffedPullRequestsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUnderstaffedPullRequests not implemented")
+}
+func (UnimplementedPRManagerServer) mustEmbedUnimplementedPRManagerServer() {}
+func (UnimplementedPRManagerServer) testEmbeddedByValue()                   {}
+
+// UnsafePRManagerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PRManagerServer will
+// result in compilation errors.
+type UnsafePRManagerServer interface {
+	mustEmbedUnimplementedPRManagerServer()
+}
+
+func RegisterPRManagerServer(s grpc.ServiceRegistrar, srv PRManagerServer) {
+	// If the following call panics, it indicates UnimplementedPRManagerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PRManager_ServiceDesc, srv)
+}
+
+func _PRManager_CreateTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTeamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PRManagerServer).CreateTeam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PRManager_CreateTeam_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PRManagerServer).CreateTeam(ctx, req.(*CreateTeamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PRManager_GetTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTeamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PRManagerServer).GetTeam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PRManager_GetTeam_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PRManagerServer).GetTeam(ctx, req.(*GetTeamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PRManager_SetTeamStrategy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTeamStrategyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PRManagerServer).SetTeamStrategy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PRManager_SetTeamStrategy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PRManagerServer).SetTeamStrategy(ctx, req.(*SetTeamStrategyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PRManager_SetUserActive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserActiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PRManagerServer).SetUserActive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PRManager_SetUserActive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PRManagerServer).SetUserActive(ctx, req.(*SetUserActiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PRManager_SetUserExpertise_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserExpertiseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PRManagerServer).SetUserExpertise(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PRManager_SetUserExpertise_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PRManagerServer).SetUserExpertise(ctx, req.(*SetUserExpertiseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PRManager_SetUserMaxOpenReviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserMaxOpenReviewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PRManagerServer).SetUserMaxOpenReviews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PRManager_SetUserMaxOpenReviews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PRManagerServer).SetUserMaxOpenReviews(ctx, req.(*SetUserMaxOpenReviewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PRManager_SetUserReviewWeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserReviewWeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PRManagerServer).SetUserReviewWeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PRManager_SetUserReviewWeight_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PRManagerServer).SetUserReviewWeight(ctx, req.(*SetUserReviewWeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PRManager_SetUserTrainee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserTraineeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PRManagerServer).SetUserTrainee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PRManager_SetUserTrainee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PRManagerServer).SetUserTrainee(ctx, req.(*SetUserTraineeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PRManager_GetUserReviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserReviewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PRManagerServer).GetUserReviews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PRManager_GetUserReviews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PRManagerServer).GetUserReviews(ctx, req.(*GetUserReviewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PRManager_CreatePullRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePullRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PRManagerServer).CreatePullRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PRManager_CreatePullRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PRManagerServer).CreatePullRequest(ctx, req.(*CreatePullRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PRManager_GetPullRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPullRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PRManagerServer).GetPullRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PRManager_GetPullRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PRManagerServer).GetPullRequest(ctx, req.(*GetPullRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PRManager_ListPullRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPullRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PRManagerServer).ListPullRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PRManager_ListPullRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PRManagerServer).ListPullRequests(ctx, req.(*ListPullRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PRManager_MergePullRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergePullRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PRManagerServer).MergePullRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PRManager_MergePullRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PRManagerServer).MergePullRequest(ctx, req.(*MergePullRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PRManager_ReassignReviewer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReassignReviewerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PRManagerServer).ReassignReviewer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PRManager_ReassignReviewer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PRManagerServer).ReassignReviewer(ctx, req.(*ReassignReviewerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PRManager_GetUnderstaffedPullRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUnderstaffedPullRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PRManagerServer).GetUnderstaffedPullRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PRManager_GetUnderstaffedPullRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PRManagerServer).GetUnderstaffedPullRequests(ctx, req.(*GetUnderstaffedPullRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PRManager_ServiceDesc is the grpc.ServiceDesc for PRManager service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PRManager_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "prmanager.v1.PRManager",
+	HandlerType: (*PRManagerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTeam",
+			Handler:    _PRManager_CreateTeam_Handler,
+		},
+		{
+			MethodName: "GetTeam",
+			Handler:    _PRManager_GetTeam_Handler,
+		},
+		{
+			MethodName: "SetTeamStrategy",
+			Handler:    _PRManager_SetTeamStrategy_Handler,
+		},
+		{
+			MethodName: "SetUserActive",
+			Handler:    _PRManager_SetUserActive_Handler,
+		},
+		{
+			MethodName: "SetUserExpertise",
+			Handler:    _PRManager_SetUserExpertise_Handler,
+		},
+		{
+			MethodName: "SetUserMaxOpenReviews",
+			Handler:    _PRManager_SetUserMaxOpenReviews_Handler,
+		},
+		{
+			MethodName: "SetUserReviewWeight",
+			Handler:    _PRManager_SetUserReviewWeight_Handler,
+		},
+		{
+			MethodName: "SetUserTrainee",
+			Handler:    _PRManager_SetUserTrainee_Handler,
+		},
+		{
+			MethodName: "GetUserReviews",
+			Handler:    _PRManager_GetUserReviews_Handler,
+		},
+		{
+			MethodName: "CreatePullRequest",
+			Handler:    _PRManager_CreatePullRequest_Handler,
+		},
+		{
+			MethodName: "GetPullRequest",
+			Handler:    _PRManager_GetPullRequest_Handler,
+		},
+		{
+			MethodName: "ListPullRequests",
+			Handler:    _PRManager_ListPullRequests_Handler,
+		},
+		{
+			MethodName: "MergePullRequest",
+			Handler:    _PRManager_MergePullRequest_Handler,
+		},
+		{
+			MethodName: "ReassignReviewer",
+			Handler:    _PRManager_ReassignReviewer_Handler,
+		},
+		{
+			MethodName: "GetUnderstaffedPullRequests",
+			Handler:    _PRManager_GetUnderstaffedPullRequests_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "prmanager/v1/prmanager.proto",
+}
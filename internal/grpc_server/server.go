@@ -0,0 +1,219 @@
+// Package grpcserver - gRPC API (proto/prmanager/v1) поверх того же сервиса,
+// что и HTTP-обработчики.
+package grpcserver
+
+import (
+	"context"
+	"log"
+	"prmanager/internal/grpc_server/pb"
+	"prmanager/internal/handlers/interfaces"
+	"prmanager/internal/models"
+	"strings"
+
+	"google.golang.org/grpc"
+)
+
+type Server struct {
+	pb.UnimplementedPRManagerServer
+
+	service interfaces.Service
+	logger  *log.Logger
+}
+
+func NewServer(service interfaces.Service, logger *log.Logger) *Server {
+	return &Server{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Register регистрирует PRManager на gRPC-сервере
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	pb.RegisterPRManagerServer(registrar, s)
+}
+
+// Teams
+
+func (s *Server) CreateTeam(ctx context.Context, req *pb.CreateTeamRequest) (*pb.Team, error) {
+	if req.GetTeam().GetTeamName() == "" {
+		return nil, s.invalidArgument("team.team_name is required")
+	}
+
+	team, err := s.service.CreateTeam(ctx, teamFromProto(req.GetTeam()))
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+	return teamToProto(team), nil
+}
+
+func (s *Server) GetTeam(ctx context.Context, req *pb.GetTeamRequest) (*pb.Team, error) {
+	team, err := s.service.GetTeam(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+	return teamToProto(team), nil
+}
+
+func (s *Server) SetTeamStrategy(ctx context.Context, req *pb.SetTeamStrategyRequest) (*pb.Team, error) {
+	team, err := s.service.SetTeamStrategy(ctx, req.GetTeamName(), req.GetAssignmentStrategy())
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+	return teamToProto(team), nil
+}
+
+// Users
+
+func (s *Server) SetUserActive(ctx context.Context, req *pb.SetUserActiveRequest) (*pb.User, error) {
+	return s.userResult(s.service.SetUserActive(ctx, req.GetUserId(), req.GetIsActive()))
+}
+
+func (s *Server) SetUserExpertise(ctx context.Context, req *pb.SetUserExpertiseRequest) (*pb.User, error) {
+	return s.userResult(s.service.SetUserExpertise(ctx, req.GetUserId(), req.GetExpertise()))
+}
+
+func (s *Server) SetUserMaxOpenReviews(ctx context.Context, req *pb.SetUserMaxOpenReviewsRequest) (*pb.User, error) {
+	var maxOpenReviews *int
+	if req.MaxOpenReviews != nil {
+		value := int(req.GetMaxOpenReviews())
+		maxOpenReviews = &value
+	}
+	return s.userResult(s.service.SetUserMaxOpenReviews(ctx, req.GetUserId(), maxOpenReviews))
+}
+
+func (s *Server) SetUserReviewWeight(ctx context.Context, req *pb.SetUserReviewWeightRequest) (*pb.User, error) {
+	return s.userResult(s.service.SetUserReviewWeight(ctx, req.GetUserId(), req.GetReviewWeight()))
+}
+
+func (s *Server) SetUserTrainee(ctx context.Context, req *pb.SetUserTraineeRequest) (*pb.User, error) {
+	return s.userResult(s.service.SetUserTrainee(ctx, req.GetUserId(), req.GetIsTrainee()))
+}
+
+func (s *Server) GetUserReviews(ctx context.Context, req *pb.GetUserReviewsRequest) (*pb.GetUserReviewsResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, s.invalidArgument("user_id is required")
+	}
+
+	filter := models.ReviewFilter{
+		ReviewerID: req.GetUserId(),
+		Status:     strings.ToUpper(req.GetStatus()),
+		AuthorID:   req.GetAuthorId(),
+		Repository: req.GetRepository(),
+		From:       timeFromProto(req.GetFrom()),
+		To:         timeFromProto(req.GetTo()),
+		SortBy:     req.GetSortBy(),
+		Ascending:  req.GetAscending(),
+		Limit:      int(req.GetLimit()),
+	}
+
+	page, err := s.service.GetUserReviews(ctx, filter, req.GetCursor())
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	resp := &pb.GetUserReviewsResponse{NextCursor: page.NextCursor}
+	for _, review := range page.PullRequests {
+		resp.PullRequests = append(resp.PullRequests, userReviewToProto(review))
+	}
+	return resp, nil
+}
+
+// Pull Requests
+
+func (s *Server) CreatePullRequest(ctx context.Context, req *pb.CreatePullRequestRequest) (*pb.PullRequest, error) {
+	pr, err := s.service.CreatePullRequest(ctx, &models.PullRequest{
+		PullRequestID:   req.GetPullRequestId(),
+		PullRequestName: req.GetPullRequestName(),
+		AuthorID:        req.GetAuthorId(),
+		Repository:      req.GetRepository(),
+		Number:          int(req.GetNumber()),
+		Files:           req.GetFiles(),
+	})
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+	return pullRequestToProto(pr), nil
+}
+
+func (s *Server) GetPullRequest(ctx context.Context, req *pb.GetPullRequestRequest) (*pb.PullRequest, error) {
+	pr, err := s.service.GetPullRequest(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+	return pullRequestToProto(pr), nil
+}
+
+func (s *Server) ListPullRequests(ctx context.Context, req *pb.ListPullRequestsRequest) (*pb.ListPullRequestsResponse, error) {
+	filter := models.PullRequestFilter{
+		Status:      strings.ToUpper(req.GetStatus()),
+		AuthorID:    req.GetAuthorId(),
+		TeamName:    req.GetTeamName(),
+		ReviewerID:  req.GetReviewerId(),
+		Repository:  req.GetRepository(),
+		CreatedFrom: timeFromProto(req.GetCreatedFrom()),
+		CreatedTo:   timeFromProto(req.GetCreatedTo()),
+		MergedFrom:  timeFromProto(req.GetMergedFrom()),
+		MergedTo:    timeFromProto(req.GetMergedTo()),
+		Query:       req.GetQuery(),
+		SortBy:      req.GetSortBy(),
+		Ascending:   req.GetAscending(),
+		Limit:       int(req.GetLimit()),
+	}
+
+	page, err := s.service.ListPullRequests(ctx, filter, req.GetCursor())
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	resp := &pb.ListPullRequestsResponse{NextCursor: page.NextCursor}
+	for _, item := range page.PullRequests {
+		pr := pullRequestToProto(&item.PullRequest)
+		pr.Rank = item.Rank
+		resp.PullRequests = append(resp.PullRequests, pr)
+	}
+	return resp, nil
+}
+
+func (s *Server) MergePullRequest(ctx context.Context, req *pb.MergePullRequestRequest) (*pb.PullRequest, error) {
+	pr, err := s.service.MergePullRequest(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+	return pullRequestToProto(pr), nil
+}
+
+func (s *Server) ReassignReviewer(ctx context.Context, req *pb.ReassignReviewerRequest) (*pb.ReassignReviewerResponse, error) {
+	if req.GetPullRequestId() == "" || req.GetOldUserId() == "" {
+		return nil, s.invalidArgument("pull_request_id and old_user_id are required")
+	}
+
+	result, err := s.service.ReassignReviewer(ctx, req.GetPullRequestId(), req.GetOldUserId())
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	return &pb.ReassignReviewerResponse{
+		PullRequest: pullRequestToProto(result.PR),
+		ReplacedBy:  result.NewReviewerID,
+	}, nil
+}
+
+func (s *Server) GetUnderstaffedPullRequests(ctx context.Context, _ *pb.GetUnderstaffedPullRequestsRequest) (*pb.GetUnderstaffedPullRequestsResponse, error) {
+	prs, err := s.service.GetUnderstaffedPullRequests(ctx)
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	resp := &pb.GetUnderstaffedPullRequestsResponse{}
+	for _, pr := range prs {
+		resp.PullRequests = append(resp.PullRequests, understaffedToProto(pr))
+	}
+	return resp, nil
+}
+
+func (s *Server) userResult(user *models.User, err error) (*pb.User, error) {
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+	return userToProto(user), nil
+}
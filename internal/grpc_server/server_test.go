@@ -0,0 +1,407 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"prmanager/internal/grpc_server/pb"
+	"prmanager/internal/handlers/interfaces"
+	"prmanager/internal/models"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeService отвечает на вызовы gRPC API фиксированными данными или ошибкой err.
+// Остальные методы interfaces.Service не вызываются (встроенный nil).
+type fakeService struct {
+	interfaces.Service
+	err error
+}
+
+var testCreatedAt = time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+func testPullRequest(id string) *models.PullRequest {
+	return &models.PullRequest{
+		PullRequestID:     id,
+		PullRequestName:   "Add search",
+		AuthorID:          "u1",
+		Status:            "OPEN",
+		AssignedReviewers: []string{"u2", "u3"},
+		CreatedAt:         testCreatedAt,
+	}
+}
+
+func (f *fakeService) CreateTeam(_ context.Context, team *models.Team) (*models.Team, error) {
+	return team, f.err
+}
+
+func (f *fakeService) GetTeam(_ context.Context, teamName string) (*models.Team, error) {
+	return &models.Team{TeamName: teamName, Members: []models.User{{UserID: "u1", Username: "Alice", TeamName: teamName, IsActive: true}}}, f.err
+}
+
+func (f *fakeService) SetTeamStrategy(_ context.Context, teamName, strategy string) (*models.Team, error) {
+	return &models.Team{TeamName: teamName, AssignmentStrategy: strategy}, f.err
+}
+
+func (f *fakeService) SetUserActive(_ context.Context, userID string, isActive bool) (*models.User, error) {
+	return &models.User{UserID: userID, IsActive: isActive}, f.err
+}
+
+func (f *fakeService) SetUserExpertise(_ context.Context, userID string, expertise []string) (*models.User, error) {
+	return &models.User{UserID: userID, Expertise: expertise}, f.err
+}
+
+func (f *fakeService) SetUserMaxOpenReviews(_ context.Context, userID string, maxOpenReviews *int) (*models.User, error) {
+	return &models.User{UserID: userID, MaxOpenReviews: maxOpenReviews}, f.err
+}
+
+func (f *fakeService) SetUserReviewWeight(_ context.Context, userID string, weight float64) (*models.User, error) {
+	return &models.User{UserID: userID, ReviewWeight: weight}, f.err
+}
+
+func (f *fakeService) SetUserTrainee(_ context.Context, userID string, isTrainee bool) (*models.User, error) {
+	return &models.User{UserID: userID, IsTrainee: isTrainee}, f.err
+}
+
+func (f *fakeService) GetUserReviews(_ context.Context, filter models.ReviewFilter, _ string) (*models.ReviewPage, error) {
+	return &models.ReviewPage{
+		PullRequests: []*models.UserReview{{
+			PullRequestShort: models.PullRequestShort{PullRequestID: "pr-1", AuthorID: "u1", Status: filter.Status},
+			CreatedAt:        testCreatedAt,
+			AssignedAt:       testCreatedAt,
+		}},
+		NextCursor: "next",
+	}, f.err
+}
+
+func (f *fakeService) CreatePullRequest(_ context.Context, pr *models.PullRequest) (*models.PullRequest, error) {
+	created := testPullRequest(pr.PullRequestID)
+	created.Files = pr.Files
+	return created, f.err
+}
+
+func (f *fakeService) GetPullRequest(_ context.Context, prID string) (*models.PullRequest, error) {
+	return testPullRequest(prID), f.err
+}
+
+func (f *fakeService) ListPullRequests(_ context.Context, _ models.PullRequestFilter, _ string) (*models.PullRequestPage, error) {
+	rank := 0.5
+	return &models.PullRequestPage{
+		PullRequests: []*models.PullRequestListItem{{PullRequest: *testPullRequest("pr-1"), Rank: &rank}},
+	}, f.err
+}
+
+func (f *fakeService) MergePullRequest(_ context.Context, prID string) (*models.PullRequest, error) {
+	pr := testPullRequest(prID)
+	pr.Status = "MERGED"
+	pr.MergedAt = &testCreatedAt
+	return pr, f.err
+}
+
+func (f *fakeService) ReassignReviewer(_ context.Context, prID, _ string) (*models.ReassignResult, error) {
+	return &models.ReassignResult{PR: testPullRequest(prID), NewReviewerID: "u4"}, f.err
+}
+
+func (f *fakeService) GetUnderstaffedPullRequests(context.Context) ([]*models.UnderstaffedPullRequest, error) {
+	return []*models.UnderstaffedPullRequest{{
+		PullRequestShort: models.PullRequestShort{PullRequestID: "pr-1"},
+		DesiredCount:     2,
+		AssignedCount:    1,
+		QueuedAt:         testCreatedAt,
+	}}, f.err
+}
+
+// startServer поднимает сервер на bufconn и возвращает клиент к нему
+func startServer(t *testing.T, service interfaces.Service) pb.PRManagerClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	NewServer(service, log.New(io.Discard, "", 0)).Register(grpcServer)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial bufconn: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewPRManagerClient(conn)
+}
+
+func TestRPCs(t *testing.T) {
+	client := startServer(t, &fakeService{})
+	ctx := context.Background()
+
+	t.Run("CreateTeam", func(t *testing.T) {
+		team, err := client.CreateTeam(ctx, &pb.CreateTeamRequest{Team: &pb.Team{
+			TeamName: "backend",
+			Members:  []*pb.User{{UserId: "u1", Username: "Alice", IsActive: true}},
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if team.GetTeamName() != "backend" || len(team.GetMembers()) != 1 || team.GetMembers()[0].GetTeamName() != "backend" {
+			t.Errorf("unexpected team %v", team)
+		}
+	})
+
+	t.Run("GetTeam", func(t *testing.T) {
+		team, err := client.GetTeam(ctx, &pb.GetTeamRequest{TeamName: "backend"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if team.GetTeamName() != "backend" || team.GetMembers()[0].GetUserId() != "u1" {
+			t.Errorf("unexpected team %v", team)
+		}
+	})
+
+	t.Run("SetTeamStrategy", func(t *testing.T) {
+		team, err := client.SetTeamStrategy(ctx, &pb.SetTeamStrategyRequest{TeamName: "backend", AssignmentStrategy: "round_robin"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if team.GetAssignmentStrategy() != "round_robin" {
+			t.Errorf("strategy = %q", team.GetAssignmentStrategy())
+		}
+	})
+
+	t.Run("SetUserActive", func(t *testing.T) {
+		user, err := client.SetUserActive(ctx, &pb.SetUserActiveRequest{UserId: "u1", IsActive: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if user.GetUserId() != "u1" || !user.GetIsActive() {
+			t.Errorf("unexpected user %v", user)
+		}
+	})
+
+	t.Run("SetUserExpertise", func(t *testing.T) {
+		user, err := client.SetUserExpertise(ctx, &pb.SetUserExpertiseRequest{UserId: "u1", Expertise: []string{"go"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(user.GetExpertise()) != 1 || user.GetExpertise()[0] != "go" {
+			t.Errorf("expertise = %v", user.GetExpertise())
+		}
+	})
+
+	t.Run("SetUserMaxOpenReviews", func(t *testing.T) {
+		limit := int32(3)
+		user, err := client.SetUserMaxOpenReviews(ctx, &pb.SetUserMaxOpenReviewsRequest{UserId: "u1", MaxOpenReviews: &limit})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if user.MaxOpenReviews == nil || user.GetMaxOpenReviews() != 3 {
+			t.Errorf("max_open_reviews = %v", user.MaxOpenReviews)
+		}
+
+		user, err = client.SetUserMaxOpenReviews(ctx, &pb.SetUserMaxOpenReviewsRequest{UserId: "u1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if user.MaxOpenReviews != nil {
+			t.Errorf("cleared max_open_reviews = %v", user.GetMaxOpenReviews())
+		}
+	})
+
+	t.Run("SetUserReviewWeight", func(t *testing.T) {
+		user, err := client.SetUserReviewWeight(ctx, &pb.SetUserReviewWeightRequest{UserId: "u1", ReviewWeight: 0.5})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if user.GetReviewWeight() != 0.5 {
+			t.Errorf("review_weight = %v", user.GetReviewWeight())
+		}
+	})
+
+	t.Run("SetUserTrainee", func(t *testing.T) {
+		user, err := client.SetUserTrainee(ctx, &pb.SetUserTraineeRequest{UserId: "u1", IsTrainee: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !user.GetIsTrainee() {
+			t.Error("is_trainee = false")
+		}
+	})
+
+	t.Run("GetUserReviews", func(t *testing.T) {
+		resp, err := client.GetUserReviews(ctx, &pb.GetUserReviewsRequest{UserId: "u2", Status: "open"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.GetPullRequests()) != 1 || resp.GetNextCursor() != "next" {
+			t.Fatalf("unexpected response %v", resp)
+		}
+		if status := resp.GetPullRequests()[0].GetStatus(); status != "OPEN" {
+			t.Errorf("status filter passed as %q, want OPEN", status)
+		}
+	})
+
+	t.Run("CreatePullRequest", func(t *testing.T) {
+		pr, err := client.CreatePullRequest(ctx, &pb.CreatePullRequestRequest{
+			PullRequestId:   "pr-1",
+			PullRequestName: "Add search",
+			AuthorId:        "u1",
+			Files:           []string{"main.go"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pr.GetPullRequestId() != "pr-1" || len(pr.GetAssignedReviewers()) != 2 {
+			t.Errorf("unexpected pull request %v", pr)
+		}
+		if !pr.GetCreatedAt().AsTime().Equal(testCreatedAt) {
+			t.Errorf("created_at = %v", pr.GetCreatedAt().AsTime())
+		}
+	})
+
+	t.Run("GetPullRequest", func(t *testing.T) {
+		pr, err := client.GetPullRequest(ctx, &pb.GetPullRequestRequest{PullRequestId: "pr-1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pr.GetPullRequestId() != "pr-1" || pr.GetStatus() != "OPEN" {
+			t.Errorf("unexpected pull request %v", pr)
+		}
+	})
+
+	t.Run("ListPullRequests", func(t *testing.T) {
+		resp, err := client.ListPullRequests(ctx, &pb.ListPullRequestsRequest{Query: "search"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.GetPullRequests()) != 1 || resp.GetPullRequests()[0].Rank == nil || resp.GetPullRequests()[0].GetRank() != 0.5 {
+			t.Errorf("unexpected response %v", resp)
+		}
+	})
+
+	t.Run("MergePullRequest", func(t *testing.T) {
+		pr, err := client.MergePullRequest(ctx, &pb.MergePullRequestRequest{PullRequestId: "pr-1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pr.GetStatus() != "MERGED" || pr.GetMergedAt() == nil {
+			t.Errorf("unexpected pull request %v", pr)
+		}
+	})
+
+	t.Run("ReassignReviewer", func(t *testing.T) {
+		resp, err := client.ReassignReviewer(ctx, &pb.ReassignReviewerRequest{PullRequestId: "pr-1", OldUserId: "u2"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.GetReplacedBy() != "u4" || resp.GetPullRequest().GetPullRequestId() != "pr-1" {
+			t.Errorf("unexpected response %v", resp)
+		}
+	})
+
+	t.Run("GetUnderstaffedPullRequests", func(t *testing.T) {
+		resp, err := client.GetUnderstaffedPullRequests(ctx, &pb.GetUnderstaffedPullRequestsRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.GetPullRequests()) != 1 || resp.GetPullRequests()[0].GetDesiredCount() != 2 {
+			t.Errorf("unexpected response %v", resp)
+		}
+	})
+}
+
+func TestErrorMapping(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		err    error
+		code   codes.Code
+		reason string
+	}{
+		{errors.New("INVALID_REQUEST"), codes.InvalidArgument, "INVALID_REQUEST"},
+		{errors.New("INVALID_CURSOR"), codes.InvalidArgument, "INVALID_CURSOR"},
+		{errors.New("INVALID_STRATEGY"), codes.InvalidArgument, "INVALID_STRATEGY"},
+		{errors.New("NOT_FOUND"), codes.NotFound, "NOT_FOUND"},
+		{errors.New("TEAM_NOT_FOUND"), codes.NotFound, "TEAM_NOT_FOUND"},
+		{errors.New("AUTHOR_NOT_FOUND"), codes.NotFound, "AUTHOR_NOT_FOUND"},
+		{errors.New("REPOSITORY_NOT_FOUND"), codes.NotFound, "REPOSITORY_NOT_FOUND"},
+		{errors.New("TEAM_EXISTS"), codes.AlreadyExists, "TEAM_EXISTS"},
+		{errors.New("PR_EXISTS"), codes.AlreadyExists, "PR_EXISTS"},
+		{errors.New("PR_MERGED"), codes.FailedPrecondition, "PR_MERGED"},
+		{errors.New("NOT_ASSIGNED"), codes.FailedPrecondition, "NOT_ASSIGNED"},
+		{errors.New("NO_CANDIDATE"), codes.FailedPrecondition, "NO_CANDIDATE"},
+		{errors.New("connection refused"), codes.Internal, ""},
+	}
+
+	// Новый код ошибки сервиса должен попасть и в эту таблицу
+	covered := make(map[string]bool, len(tests))
+	for _, tt := range tests {
+		covered[tt.reason] = true
+	}
+	for reason := range serviceErrors {
+		if !covered[reason] {
+			t.Errorf("service error %s is not covered", reason)
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			client := startServer(t, &fakeService{err: tt.err})
+
+			_, err := client.ReassignReviewer(ctx, &pb.ReassignReviewerRequest{PullRequestId: "pr-1", OldUserId: "u2"})
+			assertStatus(t, err, tt.code, tt.reason)
+		})
+	}
+}
+
+// Обязательные поля проверяются до обращения к сервису
+func TestRequiredFields(t *testing.T) {
+	client := startServer(t, &fakeService{})
+	ctx := context.Background()
+
+	_, err := client.CreateTeam(ctx, &pb.CreateTeamRequest{Team: &pb.Team{}})
+	assertStatus(t, err, codes.InvalidArgument, "INVALID_REQUEST")
+
+	_, err = client.GetUserReviews(ctx, &pb.GetUserReviewsRequest{})
+	assertStatus(t, err, codes.InvalidArgument, "INVALID_REQUEST")
+
+	_, err = client.ReassignReviewer(ctx, &pb.ReassignReviewerRequest{PullRequestId: "pr-1"})
+	assertStatus(t, err, codes.InvalidArgument, "INVALID_REQUEST")
+}
+
+func assertStatus(t *testing.T, err error, code codes.Code, reason string) {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("error %v is not a gRPC status", err)
+	}
+	if st.Code() != code {
+		t.Errorf("code = %s, want %s", st.Code(), code)
+	}
+
+	var got string
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			if info.GetDomain() != errorDomain {
+				t.Errorf("ErrorInfo.domain = %q, want %q", info.GetDomain(), errorDomain)
+			}
+			got = info.GetReason()
+		}
+	}
+	if got != reason {
+		t.Errorf("ErrorInfo.reason = %q, want %q", got, reason)
+	}
+}
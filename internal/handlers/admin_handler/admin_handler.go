@@ -0,0 +1,112 @@
+package adminhandler
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"log"
+	"net/http"
+	"prmanager/internal/handlers/interfaces"
+	"prmanager/internal/models"
+	"strings"
+	"time"
+)
+
+// maxArchiveSize - ограничение на размер архива в теле запроса;
+// большие архивы восстанавливаются командой restore
+const maxArchiveSize = 10 << 20
+
+type Handler struct {
+	service interfaces.Service
+	logger  *log.Logger
+}
+
+func NewHandler(service interfaces.Service, logger *log.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RequireToken пропускает запросы к /admin/* только с заголовком
+// Authorization: Bearer <token>, остальные маршруты не трогает. Подключается
+// раньше проверки по спецификации и ключей идемпотентности: без токена тело
+// архива не разбирается и ключ не занимается.
+func (h *Handler) RequireToken(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.URL.Path, "/admin/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				h.writeError(w, "UNAUTHORIZED", "valid admin bearer token is required", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Export отдает архив всех данных сервиса как файл для скачивания
+func (h *Handler) Export(w http.ResponseWriter, r *http.Request) {
+	archive, err := h.service.ExportArchive(r.Context())
+	if err != nil {
+		h.writeError(w, "INTERNAL_ERROR", "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	filename := "prmanager-export-" + archive.ExportedAt.Format(time.DateOnly) + ".json"
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	json.NewEncoder(w).Encode(archive)
+}
+
+// Import восстанавливает архив из тела запроса в пустую базу
+func (h *Handler) Import(w http.ResponseWriter, r *http.Request) {
+	var archive models.Archive
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxArchiveSize)).Decode(&archive); err != nil {
+		h.writeError(w, "INVALID_REQUEST", "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	summary, err := h.service.RestoreArchive(r.Context(), &archive)
+	if err != nil {
+		switch err.Error() {
+		case "UNSUPPORTED_ARCHIVE_VERSION":
+			h.writeError(w, "UNSUPPORTED_ARCHIVE_VERSION", "archive version is not supported", http.StatusBadRequest)
+		case "INVALID_ARCHIVE":
+			h.writeError(w, "INVALID_ARCHIVE", "archive references missing teams, users or repositories or has invalid values", http.StatusBadRequest)
+		case "DATABASE_NOT_EMPTY":
+			h.writeError(w, "DATABASE_NOT_EMPTY", "archive can only be restored into an empty database", http.StatusConflict)
+		default:
+			h.writeError(w, "INTERNAL_ERROR", "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"restored": summary,
+	})
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, code, message string, status int) {
+	h.logger.Printf("Admin Error: %s - %s (status: %d)", code, message, status)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	errorResp := struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}{}
+	errorResp.Error.Code = code
+	errorResp.Error.Message = message
+
+	json.NewEncoder(w).Encode(errorResp)
+}
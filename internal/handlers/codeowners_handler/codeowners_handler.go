@@ -0,0 +1,111 @@
+package codeownershandler
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"prmanager/internal/handlers/interfaces"
+)
+
+// maxFileSize - ограничение на размер загружаемого CODEOWNERS
+const maxFileSize = 1 << 20
+
+type Handler struct {
+	service interfaces.Service
+	logger  *log.Logger
+}
+
+func NewHandler(service interfaces.Service, logger *log.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// UploadCodeowners принимает JSON {"repository", "content"} либо сам файл
+// в теле запроса (text/plain) с репозиторием в параметре repository.
+func (h *Handler) UploadCodeowners(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Repository string `json:"repository"`
+		Content    string `json:"content"`
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxFileSize)
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "application/json" {
+		if err := json.NewDecoder(body).Decode(&req); err != nil {
+			h.writeError(w, "INVALID_REQUEST", "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	} else {
+		content, err := io.ReadAll(body)
+		if err != nil {
+			h.writeError(w, "INVALID_REQUEST", "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		req.Repository = r.URL.Query().Get("repository")
+		req.Content = string(content)
+	}
+
+	if req.Repository == "" {
+		h.writeError(w, "INVALID_REQUEST", "repository is required", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.SetCodeowners(r.Context(), req.Repository, req.Content)
+	if err != nil {
+		switch err.Error() {
+		case "INVALID_CODEOWNERS":
+			h.writeError(w, "INVALID_CODEOWNERS", "CODEOWNERS file could not be parsed", http.StatusBadRequest)
+		case "REPOSITORY_NOT_FOUND":
+			h.writeError(w, "NOT_FOUND", "Repository not found", http.StatusNotFound)
+		default:
+			h.writeError(w, "INTERNAL_ERROR", "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"codeowners": result,
+	})
+}
+
+func (h *Handler) GetCodeowners(w http.ResponseWriter, r *http.Request) {
+	repository := r.URL.Query().Get("repository")
+	if repository == "" {
+		h.writeError(w, "INVALID_REQUEST", "repository is required", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.GetCodeowners(r.Context(), repository)
+	if err != nil {
+		h.writeError(w, "NOT_FOUND", "CODEOWNERS not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"codeowners": result,
+	})
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, code, message string, status int) {
+	h.logger.Printf("Codeowners Error: %s - %s (status: %d)", code, message, status)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	errorResp := struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}{}
+	errorResp.Error.Code = code
+	errorResp.Error.Message = message
+
+	json.NewEncoder(w).Encode(errorResp)
+}
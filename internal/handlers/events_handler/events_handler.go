@@ -0,0 +1,171 @@
+package eventshandler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"prmanager/internal/handlers/interfaces"
+	"prmanager/internal/models"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// pollInterval - как часто поток перечитывает журнал без сигнала:
+	// так доходят события, записанные другими репликами
+	pollInterval = 2 * time.Second
+	// keepAliveInterval - комментарий-пинг, чтобы прокси не закрывали простаивающее соединение
+	keepAliveInterval = 15 * time.Second
+	// retryMillis - через сколько браузерный EventSource переподключается
+	retryMillis = 3000
+)
+
+type Handler struct {
+	service interfaces.Service
+	logger  *log.Logger
+	// closed закрывается при остановке сервера и завершает открытые потоки
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func NewHandler(service interfaces.Service, logger *log.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+		closed:  make(chan struct{}),
+	}
+}
+
+// Close завершает открытые потоки; клиенты переподключатся с Last-Event-ID
+func (h *Handler) Close() {
+	h.closeOnce.Do(func() { close(h.closed) })
+}
+
+// Stream - поток событий PR в формате server-sent events.
+// Фильтры: team_name (команда автора или ревьюера), user_id (автор или ревьюер).
+// Позиция продолжения - заголовок Last-Event-ID или параметр last_event_id;
+// без них поток начинается с новых событий.
+func (h *Handler) Stream(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := models.EventFilter{
+		TeamName: query.Get("team_name"),
+		UserID:   query.Get("user_id"),
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = query.Get("last_event_id")
+	}
+
+	ctx := r.Context()
+
+	// Подписка до первого чтения: событие между чтением и ожиданием не потеряется
+	signal, unsubscribe := h.service.SubscribePullRequestEvents()
+	defer unsubscribe()
+
+	if lastEventID != "" {
+		id, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || id < 0 {
+			h.writeError(w, "INVALID_REQUEST", "Last-Event-ID must be a non-negative integer", http.StatusBadRequest)
+			return
+		}
+		filter.AfterID = id
+	} else {
+		id, err := h.service.LastPullRequestEventID(ctx)
+		if err != nil {
+			h.logger.Printf("Events stream: %v", err)
+			h.writeError(w, "INTERNAL_ERROR", "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		filter.AfterID = id
+	}
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "retry: %d\n\n", retryMillis)
+	if err := rc.Flush(); err != nil {
+		h.logger.Printf("Events stream: flush not supported: %v", err)
+		return
+	}
+
+	poll := time.NewTicker(pollInterval)
+	defer poll.Stop()
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		if err := h.sendEvents(ctx, w, rc, &filter); err != nil {
+			h.logger.Printf("Events stream closed: %v", err)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.closed:
+			return
+		case <-signal:
+		case <-poll.C:
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// sendEvents отправляет все события после filter.AfterID и сдвигает позицию
+func (h *Handler) sendEvents(ctx context.Context, w http.ResponseWriter, rc *http.ResponseController, filter *models.EventFilter) error {
+	for {
+		events, err := h.service.GetPullRequestEvents(ctx, *filter)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		for _, event := range events {
+			data, err := json.Marshal(event)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+				return err
+			}
+			filter.AfterID = event.ID
+		}
+		if err := rc.Flush(); err != nil {
+			return err
+		}
+	}
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, code, message string, status int) {
+	h.logger.Printf("Events Error: %s - %s (status: %d)", code, message, status)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	errorResp := struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}{}
+	errorResp.Error.Code = code
+	errorResp.Error.Message = message
+
+	json.NewEncoder(w).Encode(errorResp)
+}
@@ -2,16 +2,28 @@ package handlers
 
 import (
 	"log"
+	adminhandler "prmanager/internal/handlers/admin_handler"
+	codeownershandler "prmanager/internal/handlers/codeowners_handler"
+	eventshandler "prmanager/internal/handlers/events_handler"
 	"prmanager/internal/handlers/interfaces"
 	prhandler "prmanager/internal/handlers/pr_handler"
+	repositoryhandler "prmanager/internal/handlers/repository_handler"
+	ruleshandler "prmanager/internal/handlers/rules_handler"
 	teamhandler "prmanager/internal/handlers/team_handler"
 	userhandler "prmanager/internal/handlers/user_handler"
+	v1handler "prmanager/internal/handlers/v1_handler"
 )
 
 type Handler struct {
 	TeamHandler        *teamhandler.Handler
 	UserHandler        *userhandler.Handler
 	PullRequestHandler *prhandler.Handler
+	CodeownersHandler  *codeownershandler.Handler
+	RepositoryHandler  *repositoryhandler.Handler
+	RulesHandler       *ruleshandler.Handler
+	EventsHandler      *eventshandler.Handler
+	V1Handler          *v1handler.Handler
+	AdminHandler       *adminhandler.Handler
 }
 
 func NewHandler(service interfaces.Service, logger *log.Logger) *Handler {
@@ -19,5 +31,11 @@ func NewHandler(service interfaces.Service, logger *log.Logger) *Handler {
 		TeamHandler:        teamhandler.NewHandler(service, logger),
 		UserHandler:        userhandler.NewHandler(service, logger),
 		PullRequestHandler: prhandler.NewHandler(service, logger),
+		CodeownersHandler:  codeownershandler.NewHandler(service, logger),
+		RepositoryHandler:  repositoryhandler.NewHandler(service, logger),
+		RulesHandler:       ruleshandler.NewHandler(service, logger),
+		EventsHandler:      eventshandler.NewHandler(service, logger),
+		V1Handler:          v1handler.NewHandler(service, logger),
+		AdminHandler:       adminhandler.NewHandler(service, logger),
 	}
 }
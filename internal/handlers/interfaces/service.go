@@ -2,20 +2,67 @@ package interfaces
 
 import (
 	"context"
+	"io"
 	"prmanager/internal/models"
+	"time"
 )
 
 type Service interface {
 	// Teams
 	CreateTeam(ctx context.Context, team *models.Team) (*models.Team, error)
 	GetTeam(ctx context.Context, teamName string) (*models.Team, error)
+	SetTeamStrategy(ctx context.Context, teamName, strategy string) (*models.Team, error)
 
 	// Users
 	SetUserActive(ctx context.Context, userID string, isActive bool) (*models.User, error)
-	GetUserReviews(ctx context.Context, userID string) ([]*models.PullRequestShort, error)
+	SetUserExpertise(ctx context.Context, userID string, expertise []string) (*models.User, error)
+	SetUserMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews *int) (*models.User, error)
+	SetUserReviewWeight(ctx context.Context, userID string, weight float64) (*models.User, error)
+	SetUserTrainee(ctx context.Context, userID string, isTrainee bool) (*models.User, error)
+	UpdateUser(ctx context.Context, userID string, update models.UserUpdate) (*models.User, error)
+	GetUserReviews(ctx context.Context, filter models.ReviewFilter, cursor string) (*models.ReviewPage, error)
+
+	// Out of office
+	AddOutOfOffice(ctx context.Context, ooo *models.OutOfOffice) (*models.OutOfOffice, error)
+	RemoveOutOfOffice(ctx context.Context, id string) error
+	GetOutOfOffice(ctx context.Context, userID string) ([]*models.OutOfOffice, error)
+	GetAwayUsers(ctx context.Context, date time.Time) ([]*models.AwayUser, error)
+	ImportCalendar(ctx context.Context, r io.Reader) (*models.CalendarImportResult, error)
 
 	// Pull Requests
-	CreatePullRequest(ctx context.Context, prID, title, authorID string) (*models.PullRequest, error)
+	CreatePullRequest(ctx context.Context, pr *models.PullRequest) (*models.PullRequest, error)
+	GetPullRequest(ctx context.Context, prID string) (*models.PullRequest, error)
+	ListPullRequests(ctx context.Context, filter models.PullRequestFilter, cursor string) (*models.PullRequestPage, error)
 	MergePullRequest(ctx context.Context, prID string) (*models.PullRequest, error)
 	ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (*models.ReassignResult, error)
+	GetUnderstaffedPullRequests(ctx context.Context) ([]*models.UnderstaffedPullRequest, error)
+	ImportPullRequests(ctx context.Context, items []*models.PullRequestImportItem, mode string, chunkSize int) (*models.PullRequestImportReport, error)
+	PreviewAssignment(ctx context.Context, pr *models.PullRequest) (*models.AssignmentExplanation, error)
+	GetAssignmentExplanations(ctx context.Context, prID string) ([]*models.AssignmentExplanation, error)
+
+	// Events
+	SubscribePullRequestEvents() (<-chan struct{}, func())
+	GetPullRequestEvents(ctx context.Context, filter models.EventFilter) ([]*models.PullRequestEvent, error)
+	LastPullRequestEventID(ctx context.Context) (int64, error)
+
+	// Repositories
+	CreateRepository(ctx context.Context, repo *models.Repository) (*models.Repository, error)
+	GetRepository(ctx context.Context, name string) (*models.Repository, error)
+	ListRepositories(ctx context.Context) ([]*models.Repository, error)
+	UpdateRepository(ctx context.Context, name string, reviewerCount *int, teamName *string) (*models.Repository, error)
+	DeleteRepository(ctx context.Context, name string) error
+
+	// Assignment rules
+	CreateAssignmentRule(ctx context.Context, rule *models.AssignmentRule) (*models.AssignmentRule, error)
+	DeleteAssignmentRule(ctx context.Context, id string) error
+	ListAssignmentRules(ctx context.Context, authorID string) ([]*models.AssignmentRule, error)
+	DryRunAssignment(ctx context.Context, authorID string, files []string) (*models.AssignmentExplanation, error)
+
+	// Codeowners
+	SetCodeowners(ctx context.Context, repository, content string) (*models.Codeowners, error)
+	GetCodeowners(ctx context.Context, repository string) (*models.Codeowners, error)
+
+	// Archive
+	ExportArchive(ctx context.Context) (*models.Archive, error)
+	RestoreArchive(ctx context.Context, archive *models.Archive) (*models.ArchiveSummary, error)
 }
@@ -0,0 +1,65 @@
+package params
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"prmanager/internal/models"
+	"strconv"
+)
+
+// MaxImportSize - ограничение на размер тела импорта PR
+const MaxImportSize = 10 << 20
+
+// PullRequestImport разбирает тело импорта PR: JSON-массив (application/json)
+// или по объекту на строку (application/x-ndjson), и query-параметры mode, chunk_size.
+func PullRequestImport(w http.ResponseWriter, r *http.Request) ([]*models.PullRequestImportItem, string, int, error) {
+	query := r.URL.Query()
+
+	mode := query.Get("mode")
+	if mode != "" && mode != models.ImportModeAtomic && mode != models.ImportModeChunked {
+		return nil, "", 0, errors.New("mode must be atomic or chunked")
+	}
+
+	chunkSize := 0
+	if value := query.Get("chunk_size"); value != "" {
+		var err error
+		chunkSize, err = strconv.Atoi(value)
+		if err != nil || chunkSize < 1 {
+			return nil, "", 0, errors.New("chunk_size must be a positive integer")
+		}
+	}
+
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxImportSize))
+
+	var items []*models.PullRequestImportItem
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "application/x-ndjson" {
+		for {
+			var item models.PullRequestImportItem
+			err := decoder.Decode(&item)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, "", 0, errors.New("body must contain one pull request object per line")
+			}
+			items = append(items, &item)
+		}
+	} else if err := decoder.Decode(&items); err != nil {
+		return nil, "", 0, errors.New("body must be a JSON array of pull requests")
+	}
+
+	if len(items) == 0 {
+		return nil, "", 0, errors.New("at least one pull request is required")
+	}
+	for _, item := range items {
+		if item == nil {
+			return nil, "", 0, errors.New("pull requests must be objects")
+		}
+	}
+
+	return items, mode, chunkSize, nil
+}
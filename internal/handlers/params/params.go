@@ -0,0 +1,127 @@
+// Package params разбирает query-параметры списков, общие для обработчиков
+// старых маршрутов и /api/v1. Ошибки содержат текст для ответа клиенту.
+package params
+
+import (
+	"errors"
+	"net/url"
+	"prmanager/internal/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReviewFilter - фильтры списка ревью: status, author_id, repository, from/to,
+// sort_by, order, limit. Ревьюер задается вызывающим.
+func ReviewFilter(query url.Values) (models.ReviewFilter, error) {
+	filter := models.ReviewFilter{
+		Status:     strings.ToUpper(query.Get("status")),
+		AuthorID:   query.Get("author_id"),
+		Repository: query.Get("repository"),
+		SortBy:     query.Get("sort_by"),
+	}
+
+	var err error
+	if filter.Ascending, err = Order(query); err != nil {
+		return filter, err
+	}
+	if filter.Limit, err = Limit(query); err != nil {
+		return filter, err
+	}
+	if filter.From, err = Time(query.Get("from"), false); err != nil {
+		return filter, errors.New("from must be RFC 3339 or YYYY-MM-DD")
+	}
+	if filter.To, err = Time(query.Get("to"), true); err != nil {
+		return filter, errors.New("to must be RFC 3339 or YYYY-MM-DD")
+	}
+
+	return filter, nil
+}
+
+// PullRequestFilter - фильтры списка PR: status, author_id, team_name, reviewer_id,
+// repository, created_from/created_to, merged_from/merged_to, q, sort_by, order, limit.
+func PullRequestFilter(query url.Values) (models.PullRequestFilter, error) {
+	filter := models.PullRequestFilter{
+		Status:     strings.ToUpper(query.Get("status")),
+		AuthorID:   query.Get("author_id"),
+		TeamName:   query.Get("team_name"),
+		ReviewerID: query.Get("reviewer_id"),
+		Repository: query.Get("repository"),
+		Query:      query.Get("q"),
+		SortBy:     query.Get("sort_by"),
+	}
+
+	var err error
+	if filter.Ascending, err = Order(query); err != nil {
+		return filter, err
+	}
+	if filter.Limit, err = Limit(query); err != nil {
+		return filter, err
+	}
+
+	ranges := []struct {
+		name     string
+		endOfDay bool
+		target   **time.Time
+	}{
+		{"created_from", false, &filter.CreatedFrom},
+		{"created_to", true, &filter.CreatedTo},
+		{"merged_from", false, &filter.MergedFrom},
+		{"merged_to", true, &filter.MergedTo},
+	}
+	for _, param := range ranges {
+		t, err := Time(query.Get(param.name), param.endOfDay)
+		if err != nil {
+			return filter, errors.New(param.name + " must be RFC 3339 or YYYY-MM-DD")
+		}
+		*param.target = t
+	}
+
+	return filter, nil
+}
+
+// Order - true для order=asc; по умолчанию desc
+func Order(query url.Values) (bool, error) {
+	switch query.Get("order") {
+	case "", "desc":
+		return false, nil
+	case "asc":
+		return true, nil
+	}
+	return false, errors.New("order must be asc or desc")
+}
+
+// Limit - размер страницы; 0 - по умолчанию сервиса
+func Limit(query url.Values) (int, error) {
+	value := query.Get("limit")
+	if value == "" {
+		return 0, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 {
+		return 0, errors.New("limit must be a positive integer")
+	}
+	return limit, nil
+}
+
+// Time разбирает RFC 3339 или дату YYYY-MM-DD (UTC).
+// Дата как верхняя граница (endOfDay) включает весь день.
+func Time(value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
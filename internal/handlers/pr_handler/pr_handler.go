@@ -2,9 +2,12 @@ package prhandler
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"prmanager/internal/handlers/interfaces"
+	"prmanager/internal/handlers/params"
+	"prmanager/internal/models"
 )
 
 type Handler struct {
@@ -21,9 +24,12 @@ func NewHandler(service interfaces.Service, logger *log.Logger) *Handler {
 
 func (h *Handler) CreatePullRequest(w http.ResponseWriter, r *http.Request) {
 	var req struct {
-		PullRequestID   string `json:"pull_request_id"`
-		PullRequestName string `json:"pull_request_name"`
-		AuthorID        string `json:"author_id"`
+		PullRequestID   string   `json:"pull_request_id"`
+		PullRequestName string   `json:"pull_request_name"`
+		AuthorID        string   `json:"author_id"`
+		Repository      string   `json:"repository"`
+		Number          int      `json:"number"`
+		Files           []string `json:"files"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -31,9 +37,20 @@ func (h *Handler) CreatePullRequest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pr, err := h.service.CreatePullRequest(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
+	pr, err := h.service.CreatePullRequest(r.Context(), &models.PullRequest{
+		PullRequestID:   req.PullRequestID,
+		PullRequestName: req.PullRequestName,
+		AuthorID:        req.AuthorID,
+		Repository:      req.Repository,
+		Number:          req.Number,
+		Files:           req.Files,
+	})
 	if err != nil {
 		switch err.Error() {
+		case "INVALID_REQUEST":
+			h.writeError(w, "INVALID_REQUEST", "pull_request_id or repository and number are required, number must fit a 32-bit integer", http.StatusBadRequest)
+		case "REPOSITORY_NOT_FOUND":
+			h.writeError(w, "NOT_FOUND", "Repository not found", http.StatusNotFound)
 		case "PR_EXISTS":
 			h.writeError(w, "PR_EXISTS", "PR id already exists", http.StatusConflict)
 		case "AUTHOR_NOT_FOUND", "TEAM_NOT_FOUND":
@@ -51,6 +68,55 @@ func (h *Handler) CreatePullRequest(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (h *Handler) GetPullRequest(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		h.writeError(w, "INVALID_REQUEST", "pull_request_id is required", http.StatusBadRequest)
+		return
+	}
+
+	pr, err := h.service.GetPullRequest(r.Context(), prID)
+	if err != nil {
+		h.writeError(w, "NOT_FOUND", "PR not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"pr": pr,
+	})
+}
+
+// ListPullRequests - страница PR. Фильтры: status, author_id, team_name, reviewer_id,
+// repository, created_from/created_to, merged_from/merged_to (RFC 3339 или YYYY-MM-DD);
+// поиск по названию: q; сортировка: sort_by=created|merged|relevance, order=desc|asc;
+// страница: limit, cursor.
+func (h *Handler) ListPullRequests(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	filter, err := params.PullRequestFilter(query)
+	if err != nil {
+		h.writeError(w, "INVALID_REQUEST", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.service.ListPullRequests(r.Context(), filter, query.Get("cursor"))
+	if err != nil {
+		switch err.Error() {
+		case "INVALID_REQUEST":
+			h.writeError(w, "INVALID_REQUEST", "invalid status, sort_by or limit (sort_by=relevance requires q)", http.StatusBadRequest)
+		case "INVALID_CURSOR":
+			h.writeError(w, "INVALID_REQUEST", "cursor is invalid or does not match sort order and query", http.StatusBadRequest)
+		default:
+			h.writeError(w, "INTERNAL_ERROR", "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
 func (h *Handler) MergePullRequest(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		PullRequestID string `json:"pull_request_id"`
@@ -108,6 +174,122 @@ func (h *Handler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (h *Handler) GetUnderstaffedPullRequests(w http.ResponseWriter, r *http.Request) {
+	prs, err := h.service.GetUnderstaffedPullRequests(r.Context())
+	if err != nil {
+		h.writeError(w, "INTERNAL_ERROR", "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if prs == nil {
+		prs = []*models.UnderstaffedPullRequest{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"pull_requests": prs,
+	})
+}
+
+// PreviewAssignment - кого назначили бы на PR и почему, без создания PR
+func (h *Handler) PreviewAssignment(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string   `json:"pull_request_id"`
+		AuthorID      string   `json:"author_id"`
+		Repository    string   `json:"repository"`
+		Files         []string `json:"files"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, "INVALID_REQUEST", "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.AuthorID == "" {
+		h.writeError(w, "INVALID_REQUEST", "author_id is required", http.StatusBadRequest)
+		return
+	}
+
+	explanation, err := h.service.PreviewAssignment(r.Context(), &models.PullRequest{
+		PullRequestID: req.PullRequestID,
+		AuthorID:      req.AuthorID,
+		Repository:    req.Repository,
+		Files:         req.Files,
+	})
+	if err != nil {
+		switch err.Error() {
+		case "REPOSITORY_NOT_FOUND":
+			h.writeError(w, "NOT_FOUND", "Repository not found", http.StatusNotFound)
+		case "AUTHOR_NOT_FOUND", "TEAM_NOT_FOUND":
+			h.writeError(w, "NOT_FOUND", "Author or team not found", http.StatusNotFound)
+		default:
+			h.writeError(w, "INTERNAL_ERROR", "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(explanation)
+}
+
+// ExplainAssignment - сохраненные объяснения назначений PR
+func (h *Handler) ExplainAssignment(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		h.writeError(w, "INVALID_REQUEST", "pull_request_id is required", http.StatusBadRequest)
+		return
+	}
+
+	explanations, err := h.service.GetAssignmentExplanations(r.Context(), prID)
+	if err != nil {
+		if err.Error() == "NOT_FOUND" {
+			h.writeError(w, "NOT_FOUND", "PR not found", http.StatusNotFound)
+			return
+		}
+		h.writeError(w, "INTERNAL_ERROR", "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if explanations == nil {
+		explanations = []*models.AssignmentExplanation{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"pull_request_id": prID,
+		"assignments":     explanations,
+	})
+}
+
+// ImportPullRequests создает PR пакетом: JSON-массив или NDJSON,
+// mode=atomic|chunked, chunk_size. Итог по каждому PR - в отчете.
+func (h *Handler) ImportPullRequests(w http.ResponseWriter, r *http.Request) {
+	items, mode, chunkSize, err := params.PullRequestImport(w, r)
+	if err != nil {
+		h.writeError(w, "INVALID_REQUEST", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.service.ImportPullRequests(r.Context(), items, mode, chunkSize)
+	if err != nil {
+		var rejected interface{ Message() string }
+		switch {
+		case err.Error() == "INVALID_REQUEST" && errors.As(err, &rejected):
+			h.writeError(w, "INVALID_REQUEST", rejected.Message(), http.StatusBadRequest)
+		case err.Error() == "INVALID_REQUEST":
+			h.writeError(w, "INVALID_REQUEST", "invalid import request", http.StatusBadRequest)
+		default:
+			h.writeError(w, "INTERNAL_ERROR", "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"report": report,
+	})
+}
+
 func (h *Handler) writeError(w http.ResponseWriter, code, message string, status int) {
 	h.logger.Printf("PullRequests Error: %s - %s (status: %d)", code, message, status)
 
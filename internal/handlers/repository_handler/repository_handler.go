@@ -0,0 +1,178 @@
+package repositoryhandler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"prmanager/internal/handlers/interfaces"
+	"prmanager/internal/models"
+)
+
+type Handler struct {
+	service interfaces.Service
+	logger  *log.Logger
+}
+
+func NewHandler(service interfaces.Service, logger *log.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) CreateRepository(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name                 string `json:"name"`
+		DefaultReviewerCount *int   `json:"default_reviewer_count"`
+		TeamName             string `json:"team_name"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, "INVALID_REQUEST", "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Name == "" {
+		h.writeError(w, "INVALID_REQUEST", "name is required", http.StatusBadRequest)
+		return
+	}
+
+	repo := &models.Repository{
+		Name:                 req.Name,
+		DefaultReviewerCount: 2,
+		TeamName:             req.TeamName,
+	}
+	if req.DefaultReviewerCount != nil {
+		repo.DefaultReviewerCount = *req.DefaultReviewerCount
+	}
+
+	created, err := h.service.CreateRepository(r.Context(), repo)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"repository": created,
+	})
+}
+
+func (h *Handler) GetRepository(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		h.writeError(w, "INVALID_REQUEST", "name is required", http.StatusBadRequest)
+		return
+	}
+
+	repo, err := h.service.GetRepository(r.Context(), name)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"repository": repo,
+	})
+}
+
+func (h *Handler) ListRepositories(w http.ResponseWriter, r *http.Request) {
+	repos, err := h.service.ListRepositories(r.Context())
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	if repos == nil {
+		repos = []*models.Repository{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"repositories": repos,
+	})
+}
+
+func (h *Handler) UpdateRepository(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name                 string  `json:"name"`
+		DefaultReviewerCount *int    `json:"default_reviewer_count"`
+		TeamName             *string `json:"team_name"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, "INVALID_REQUEST", "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Name == "" {
+		h.writeError(w, "INVALID_REQUEST", "name is required", http.StatusBadRequest)
+		return
+	}
+
+	repo, err := h.service.UpdateRepository(r.Context(), req.Name, req.DefaultReviewerCount, req.TeamName)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"repository": repo,
+	})
+}
+
+func (h *Handler) DeleteRepository(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name string `json:"name"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, "INVALID_REQUEST", "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteRepository(r.Context(), req.Name); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) handleError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case "INVALID_REQUEST":
+		h.writeError(w, "INVALID_REQUEST", "name must not exceed 39 characters and default_reviewer_count must not be negative", http.StatusBadRequest)
+	case "REPOSITORY_EXISTS":
+		h.writeError(w, "REPOSITORY_EXISTS", "repository already exists", http.StatusConflict)
+	case "REPOSITORY_IN_USE":
+		h.writeError(w, "REPOSITORY_IN_USE", "repository has pull requests", http.StatusConflict)
+	case "TEAM_NOT_FOUND":
+		h.writeError(w, "NOT_FOUND", "Team not found", http.StatusNotFound)
+	case "NOT_FOUND":
+		h.writeError(w, "NOT_FOUND", "Repository not found", http.StatusNotFound)
+	default:
+		h.writeError(w, "INTERNAL_ERROR", "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, code, message string, status int) {
+	h.logger.Printf("Repositories Error: %s - %s (status: %d)", code, message, status)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	errorResp := struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}{}
+	errorResp.Error.Code = code
+	errorResp.Error.Message = message
+
+	json.NewEncoder(w).Encode(errorResp)
+}
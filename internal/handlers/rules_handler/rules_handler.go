@@ -0,0 +1,158 @@
+package ruleshandler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"prmanager/internal/handlers/interfaces"
+	"prmanager/internal/models"
+	"strings"
+)
+
+type Handler struct {
+	service interfaces.Service
+	logger  *log.Logger
+}
+
+func NewHandler(service interfaces.Service, logger *log.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Kind       string `json:"kind"`
+		AuthorID   string `json:"author_id"`
+		ReviewerID string `json:"reviewer_id"`
+		Reason     string `json:"reason"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, "INVALID_REQUEST", "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.AuthorID == "" || req.ReviewerID == "" {
+		h.writeError(w, "INVALID_REQUEST", "author_id and reviewer_id are required", http.StatusBadRequest)
+		return
+	}
+
+	rule := &models.AssignmentRule{
+		Kind:       strings.ToUpper(req.Kind),
+		AuthorID:   req.AuthorID,
+		ReviewerID: req.ReviewerID,
+		Reason:     req.Reason,
+	}
+
+	created, err := h.service.CreateAssignmentRule(r.Context(), rule)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"rule": created,
+	})
+}
+
+func (h *Handler) ListRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.service.ListAssignmentRules(r.Context(), r.URL.Query().Get("author_id"))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	if rules == nil {
+		rules = []*models.AssignmentRule{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"rules": rules,
+	})
+}
+
+func (h *Handler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID string `json:"id"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, "INVALID_REQUEST", "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.ID == "" {
+		h.writeError(w, "INVALID_REQUEST", "id is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteAssignmentRule(r.Context(), req.ID); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DryRun - кто из команды автора был бы назначен и почему остальные отброшены
+func (h *Handler) DryRun(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		AuthorID string   `json:"author_id"`
+		Files    []string `json:"files"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, "INVALID_REQUEST", "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.AuthorID == "" {
+		h.writeError(w, "INVALID_REQUEST", "author_id is required", http.StatusBadRequest)
+		return
+	}
+
+	explanation, err := h.service.DryRunAssignment(r.Context(), req.AuthorID, req.Files)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(explanation)
+}
+
+func (h *Handler) handleError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case "INVALID_RULE":
+		h.writeError(w, "INVALID_RULE", "kind must be EXCLUDE or PREFER and reviewer must differ from author", http.StatusBadRequest)
+	case "RULE_EXISTS":
+		h.writeError(w, "RULE_EXISTS", "rule already exists", http.StatusConflict)
+	case "NOT_FOUND", "TEAM_NOT_FOUND":
+		h.writeError(w, "NOT_FOUND", "resource not found", http.StatusNotFound)
+	default:
+		h.writeError(w, "INTERNAL_ERROR", "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, code, message string, status int) {
+	h.logger.Printf("Assignment Rules Error: %s - %s (status: %d)", code, message, status)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	errorResp := struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}{}
+	errorResp.Error.Code = code
+	errorResp.Error.Message = message
+
+	json.NewEncoder(w).Encode(errorResp)
+}
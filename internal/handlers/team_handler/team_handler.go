@@ -34,7 +34,12 @@ func (h *Handler) CreateTeam(w http.ResponseWriter, r *http.Request) {
 
 	team, err := h.service.CreateTeam(r.Context(), &req)
 	if err != nil {
-		h.writeError(w, "ERROR_CREATING_TEAM", err.Error(), http.StatusBadRequest)
+		switch err.Error() {
+		case "INVALID_STRATEGY":
+			h.writeError(w, "INVALID_REQUEST", "assignment_strategy must be random or round_robin", http.StatusBadRequest)
+		default:
+			h.writeError(w, "ERROR_CREATING_TEAM", err.Error(), http.StatusBadRequest)
+		}
 		return
 	}
 
@@ -62,6 +67,36 @@ func (h *Handler) GetTeam(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(team)
 }
 
+func (h *Handler) SetStrategy(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TeamName           string `json:"team_name"`
+		AssignmentStrategy string `json:"assignment_strategy"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, "INVALID_REQUEST", "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	team, err := h.service.SetTeamStrategy(r.Context(), req.TeamName, req.AssignmentStrategy)
+	if err != nil {
+		switch err.Error() {
+		case "INVALID_STRATEGY":
+			h.writeError(w, "INVALID_REQUEST", "assignment_strategy must be random or round_robin", http.StatusBadRequest)
+		case "NOT_FOUND":
+			h.writeError(w, "NOT_FOUND", "Team not found", http.StatusNotFound)
+		default:
+			h.writeError(w, "INTERNAL_ERROR", "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"team": team,
+	})
+}
+
 func (h *Handler) writeError(w http.ResponseWriter, code, message string, status int) {
 	h.logger.Printf("Teams Error: %s - %s (status: %d)", code, message, status)
 
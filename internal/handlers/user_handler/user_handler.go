@@ -2,11 +2,19 @@ package userhandler
 
 import (
 	"encoding/json"
+	"io"
 	"log"
+	"mime"
 	"net/http"
 	"prmanager/internal/handlers/interfaces"
+	"prmanager/internal/handlers/params"
+	"prmanager/internal/models"
+	"time"
 )
 
+// maxCalendarSize - ограничение на размер загружаемого .ics
+const maxCalendarSize = 10 << 20
+
 type Handler struct {
 	service interfaces.Service
 	logger  *log.Logger
@@ -42,23 +50,306 @@ func (h *Handler) SetUserActive(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (h *Handler) SetUserExpertise(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID    string   `json:"user_id"`
+		Expertise []string `json:"expertise"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, "INVALID_REQUEST", "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.service.SetUserExpertise(r.Context(), req.UserID, req.Expertise)
+	if err != nil {
+		switch err.Error() {
+		case "NOT_FOUND":
+			h.writeError(w, "NOT_FOUND", "User not found", http.StatusNotFound)
+		default:
+			h.writeError(w, "INTERNAL_ERROR", "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"user": user,
+	})
+}
+
+// SetMaxOpenReviews задает лимит одновременных ревью; null снимает ограничение
+func (h *Handler) SetMaxOpenReviews(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID         string `json:"user_id"`
+		MaxOpenReviews *int   `json:"max_open_reviews"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, "INVALID_REQUEST", "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.service.SetUserMaxOpenReviews(r.Context(), req.UserID, req.MaxOpenReviews)
+	if err != nil {
+		switch err.Error() {
+		case "INVALID_REQUEST":
+			h.writeError(w, "INVALID_REQUEST", "max_open_reviews must not be negative", http.StatusBadRequest)
+		default:
+			h.writeError(w, "NOT_FOUND", "User not found", http.StatusNotFound)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"user": user,
+	})
+}
+
+func (h *Handler) SetReviewWeight(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID       string   `json:"user_id"`
+		ReviewWeight *float64 `json:"review_weight"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, "INVALID_REQUEST", "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.ReviewWeight == nil {
+		h.writeError(w, "INVALID_REQUEST", "review_weight is required", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.service.SetUserReviewWeight(r.Context(), req.UserID, *req.ReviewWeight)
+	if err != nil {
+		switch err.Error() {
+		case "INVALID_REQUEST":
+			h.writeError(w, "INVALID_REQUEST", "review_weight must not be negative", http.StatusBadRequest)
+		default:
+			h.writeError(w, "NOT_FOUND", "User not found", http.StatusNotFound)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"user": user,
+	})
+}
+
+func (h *Handler) SetTrainee(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID    string `json:"user_id"`
+		IsTrainee bool   `json:"is_trainee"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, "INVALID_REQUEST", "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.service.SetUserTrainee(r.Context(), req.UserID, req.IsTrainee)
+	if err != nil {
+		h.writeError(w, "NOT_FOUND", "User not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"user": user,
+	})
+}
+
+// GetUserReviews - страница PR, назначенных пользователю.
+// Фильтры: status, author_id, repository, from/to (RFC 3339 или YYYY-MM-DD);
+// сортировка: sort_by=created|assigned, order=desc|asc; страница: limit, cursor.
 func (h *Handler) GetUserReviews(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	userID := query.Get("user_id")
+	if userID == "" {
+		h.writeError(w, "INVALID_REQUEST", "user_id is required", http.StatusBadRequest)
+		return
+	}
+
+	filter, err := params.ReviewFilter(query)
+	if err != nil {
+		h.writeError(w, "INVALID_REQUEST", err.Error(), http.StatusBadRequest)
+		return
+	}
+	filter.ReviewerID = userID
+
+	page, err := h.service.GetUserReviews(r.Context(), filter, query.Get("cursor"))
+	if err != nil {
+		switch err.Error() {
+		case "INVALID_REQUEST":
+			h.writeError(w, "INVALID_REQUEST", "invalid status, sort_by or limit", http.StatusBadRequest)
+		case "INVALID_CURSOR":
+			h.writeError(w, "INVALID_REQUEST", "cursor is invalid or does not match sort order", http.StatusBadRequest)
+		case "NOT_FOUND":
+			h.writeError(w, "NOT_FOUND", "User not found", http.StatusNotFound)
+		default:
+			h.writeError(w, "INTERNAL_ERROR", "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"user_id":       userID,
+		"pull_requests": page.PullRequests,
+		"next_cursor":   page.NextCursor,
+	})
+}
+
+func (h *Handler) AddOutOfOffice(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID   string    `json:"user_id"`
+		StartsAt time.Time `json:"starts_at"`
+		EndsAt   time.Time `json:"ends_at"`
+		Reason   string    `json:"reason"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, "INVALID_REQUEST", "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	ooo, err := h.service.AddOutOfOffice(r.Context(), &models.OutOfOffice{
+		UserID:   req.UserID,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		switch err.Error() {
+		case "INVALID_PERIOD":
+			h.writeError(w, "INVALID_REQUEST", "ends_at must be after starts_at", http.StatusBadRequest)
+		case "NOT_FOUND":
+			h.writeError(w, "NOT_FOUND", "User not found", http.StatusNotFound)
+		default:
+			h.writeError(w, "INTERNAL_ERROR", "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"out_of_office": ooo,
+	})
+}
+
+func (h *Handler) RemoveOutOfOffice(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID string `json:"id"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, "INVALID_REQUEST", "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.RemoveOutOfOffice(r.Context(), req.ID); err != nil {
+		h.writeError(w, "NOT_FOUND", "Out of office period not found", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetOutOfOffice(w http.ResponseWriter, r *http.Request) {
 	userID := r.URL.Query().Get("user_id")
 	if userID == "" {
 		h.writeError(w, "INVALID_REQUEST", "user_id is required", http.StatusBadRequest)
 		return
 	}
 
-	prs, err := h.service.GetUserReviews(r.Context(), userID)
+	periods, err := h.service.GetOutOfOffice(r.Context(), userID)
 	if err != nil {
-		h.writeError(w, "NOT_FOUND", "User not found", http.StatusNotFound)
+		switch err.Error() {
+		case "NOT_FOUND":
+			h.writeError(w, "NOT_FOUND", "User not found", http.StatusNotFound)
+		default:
+			h.writeError(w, "INTERNAL_ERROR", "Internal server error", http.StatusInternalServerError)
+		}
 		return
 	}
 
+	if periods == nil {
+		periods = []*models.OutOfOffice{}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"user_id":       userID,
-		"pull_requests": prs,
+		"out_of_office": periods,
+	})
+}
+
+// GetAwayUsers - кто отсутствует в дату date (YYYY-MM-DD, UTC), по умолчанию сегодня
+func (h *Handler) GetAwayUsers(w http.ResponseWriter, r *http.Request) {
+	date := time.Now().UTC()
+	if value := r.URL.Query().Get("date"); value != "" {
+		parsed, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			h.writeError(w, "INVALID_REQUEST", "date must be in YYYY-MM-DD format", http.StatusBadRequest)
+			return
+		}
+		date = parsed
+	}
+
+	users, err := h.service.GetAwayUsers(r.Context(), date)
+	if err != nil {
+		h.writeError(w, "INTERNAL_ERROR", "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if users == nil {
+		users = []*models.AwayUser{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"date":  date.Format(time.DateOnly),
+		"users": users,
+	})
+}
+
+// ImportCalendar принимает .ics в теле запроса (text/calendar)
+// или в поле file формы multipart/form-data
+func (h *Handler) ImportCalendar(w http.ResponseWriter, r *http.Request) {
+	var body io.Reader = http.MaxBytesReader(w, r.Body, maxCalendarSize)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		r.Body = http.MaxBytesReader(w, r.Body, maxCalendarSize)
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			h.writeError(w, "INVALID_REQUEST", "file is required", http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		body = file
+	}
+
+	result, err := h.service.ImportCalendar(r.Context(), body)
+	if err != nil {
+		switch err.Error() {
+		case "INVALID_CALENDAR":
+			h.writeError(w, "INVALID_CALENDAR", "calendar could not be parsed", http.StatusBadRequest)
+		default:
+			h.writeError(w, "INTERNAL_ERROR", "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"result": result,
 	})
 }
 
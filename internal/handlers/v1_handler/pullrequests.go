@@ -0,0 +1,182 @@
+package v1handler
+
+import (
+	"net/http"
+	"net/url"
+	"prmanager/internal/handlers/params"
+	"prmanager/internal/models"
+)
+
+func (h *Handler) CreatePullRequest(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID   string   `json:"pull_request_id"`
+		PullRequestName string   `json:"pull_request_name"`
+		AuthorID        string   `json:"author_id"`
+		Repository      string   `json:"repository"`
+		Number          int      `json:"number"`
+		Files           []string `json:"files"`
+	}
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	pr, err := h.service.CreatePullRequest(r.Context(), &models.PullRequest{
+		PullRequestID:   req.PullRequestID,
+		PullRequestName: req.PullRequestName,
+		AuthorID:        req.AuthorID,
+		Repository:      req.Repository,
+		Number:          req.Number,
+		Files:           req.Files,
+	})
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/api/v1/pull-requests/"+url.PathEscape(pr.PullRequestID))
+	h.writeJSON(w, http.StatusCreated, pr)
+}
+
+// ListPullRequests - страница PR; параметры как у /pullRequest/list
+func (h *Handler) ListPullRequests(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	filter, err := params.PullRequestFilter(query)
+	if err != nil {
+		h.writeError(w, "INVALID_REQUEST", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.service.ListPullRequests(r.Context(), filter, query.Get("cursor"))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, page)
+}
+
+func (h *Handler) GetPullRequest(w http.ResponseWriter, r *http.Request) {
+	pr, err := h.service.GetPullRequest(r.Context(), pathParam(r, "id"))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, pr)
+}
+
+func (h *Handler) MergePullRequest(w http.ResponseWriter, r *http.Request) {
+	pr, err := h.service.MergePullRequest(r.Context(), pathParam(r, "id"))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, pr)
+}
+
+func (h *Handler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		OldUserID string `json:"old_user_id"`
+	}
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	if req.OldUserID == "" {
+		h.writeError(w, "INVALID_REQUEST", "old_user_id is required", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.ReassignReviewer(r.Context(), pathParam(r, "id"), req.OldUserID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, result)
+}
+
+// GetAssignmentExplanations - сохраненные объяснения назначений PR
+func (h *Handler) GetAssignmentExplanations(w http.ResponseWriter, r *http.Request) {
+	explanations, err := h.service.GetAssignmentExplanations(r.Context(), pathParam(r, "id"))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	if explanations == nil {
+		explanations = []*models.AssignmentExplanation{}
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"assignments": explanations,
+	})
+}
+
+// GetPendingAssignments - открытые PR, ожидающие добора ревьюеров
+func (h *Handler) GetPendingAssignments(w http.ResponseWriter, r *http.Request) {
+	prs, err := h.service.GetUnderstaffedPullRequests(r.Context())
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	if prs == nil {
+		prs = []*models.UnderstaffedPullRequest{}
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"pull_requests": prs,
+	})
+}
+
+// ImportPullRequests - POST /pull-request-imports: JSON-массив или NDJSON,
+// mode=atomic|chunked, chunk_size. Отчет по каждому PR.
+func (h *Handler) ImportPullRequests(w http.ResponseWriter, r *http.Request) {
+	items, mode, chunkSize, err := params.PullRequestImport(w, r)
+	if err != nil {
+		h.writeError(w, "INVALID_REQUEST", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.service.ImportPullRequests(r.Context(), items, mode, chunkSize)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, report)
+}
+
+// PreviewAssignment - кого назначили бы на PR и почему, без создания PR
+func (h *Handler) PreviewAssignment(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string   `json:"pull_request_id"`
+		AuthorID      string   `json:"author_id"`
+		Repository    string   `json:"repository"`
+		Files         []string `json:"files"`
+	}
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	if req.AuthorID == "" {
+		h.writeError(w, "INVALID_REQUEST", "author_id is required", http.StatusBadRequest)
+		return
+	}
+
+	explanation, err := h.service.PreviewAssignment(r.Context(), &models.PullRequest{
+		PullRequestID: req.PullRequestID,
+		AuthorID:      req.AuthorID,
+		Repository:    req.Repository,
+		Files:         req.Files,
+	})
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, explanation)
+}
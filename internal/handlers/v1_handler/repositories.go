@@ -0,0 +1,142 @@
+package v1handler
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"prmanager/internal/models"
+)
+
+// maxCodeownersSize - ограничение на размер загружаемого CODEOWNERS
+const maxCodeownersSize = 1 << 20
+
+func (h *Handler) CreateRepository(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name                 string `json:"name"`
+		DefaultReviewerCount *int   `json:"default_reviewer_count"`
+		TeamName             string `json:"team_name"`
+	}
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	if req.Name == "" {
+		h.writeError(w, "INVALID_REQUEST", "name is required", http.StatusBadRequest)
+		return
+	}
+
+	repo := &models.Repository{
+		Name:                 req.Name,
+		DefaultReviewerCount: 2,
+		TeamName:             req.TeamName,
+	}
+	if req.DefaultReviewerCount != nil {
+		repo.DefaultReviewerCount = *req.DefaultReviewerCount
+	}
+
+	created, err := h.service.CreateRepository(r.Context(), repo)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/api/v1/repositories/"+url.PathEscape(created.Name))
+	h.writeJSON(w, http.StatusCreated, created)
+}
+
+func (h *Handler) ListRepositories(w http.ResponseWriter, r *http.Request) {
+	repos, err := h.service.ListRepositories(r.Context())
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	if repos == nil {
+		repos = []*models.Repository{}
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"repositories": repos,
+	})
+}
+
+func (h *Handler) GetRepository(w http.ResponseWriter, r *http.Request) {
+	repo, err := h.service.GetRepository(r.Context(), pathParam(r, "name"))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, repo)
+}
+
+func (h *Handler) UpdateRepository(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		DefaultReviewerCount *int    `json:"default_reviewer_count"`
+		TeamName             *string `json:"team_name"`
+	}
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	repo, err := h.service.UpdateRepository(r.Context(), pathParam(r, "name"), req.DefaultReviewerCount, req.TeamName)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, repo)
+}
+
+func (h *Handler) DeleteRepository(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteRepository(r.Context(), pathParam(r, "name")); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetCodeowners(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.GetCodeowners(r.Context(), pathParam(r, "name"))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, result)
+}
+
+// PutCodeowners заменяет CODEOWNERS репозитория: файл в теле (text/plain)
+// или JSON {"content"}
+func (h *Handler) PutCodeowners(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Content string `json:"content"`
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxCodeownersSize)
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "application/json" {
+		if err := json.NewDecoder(body).Decode(&req); err != nil {
+			h.writeError(w, "INVALID_REQUEST", "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	} else {
+		content, err := io.ReadAll(body)
+		if err != nil {
+			h.writeError(w, "INVALID_REQUEST", "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		req.Content = string(content)
+	}
+
+	result, err := h.service.SetCodeowners(r.Context(), pathParam(r, "name"), req.Content)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, result)
+}
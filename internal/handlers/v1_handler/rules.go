@@ -0,0 +1,63 @@
+package v1handler
+
+import (
+	"net/http"
+	"prmanager/internal/models"
+	"strings"
+)
+
+func (h *Handler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Kind       string `json:"kind"`
+		AuthorID   string `json:"author_id"`
+		ReviewerID string `json:"reviewer_id"`
+		Reason     string `json:"reason"`
+	}
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	if req.AuthorID == "" || req.ReviewerID == "" {
+		h.writeError(w, "INVALID_REQUEST", "author_id and reviewer_id are required", http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.service.CreateAssignmentRule(r.Context(), &models.AssignmentRule{
+		Kind:       strings.ToUpper(req.Kind),
+		AuthorID:   req.AuthorID,
+		ReviewerID: req.ReviewerID,
+		Reason:     req.Reason,
+	})
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, created)
+}
+
+// ListRules - правила назначения; author_id ограничивает правилами одного автора
+func (h *Handler) ListRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.service.ListAssignmentRules(r.Context(), r.URL.Query().Get("author_id"))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	if rules == nil {
+		rules = []*models.AssignmentRule{}
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"rules": rules,
+	})
+}
+
+func (h *Handler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteAssignmentRule(r.Context(), pathParam(r, "id")); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
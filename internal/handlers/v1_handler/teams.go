@@ -0,0 +1,61 @@
+package v1handler
+
+import (
+	"net/http"
+	"net/url"
+	"prmanager/internal/models"
+)
+
+func (h *Handler) CreateTeam(w http.ResponseWriter, r *http.Request) {
+	var req models.Team
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	if req.TeamName == "" {
+		h.writeError(w, "INVALID_REQUEST", "team_name is required", http.StatusBadRequest)
+		return
+	}
+
+	team, err := h.service.CreateTeam(r.Context(), &req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/api/v1/teams/"+url.PathEscape(team.TeamName))
+	h.writeJSON(w, http.StatusCreated, team)
+}
+
+func (h *Handler) GetTeam(w http.ResponseWriter, r *http.Request) {
+	team, err := h.service.GetTeam(r.Context(), pathParam(r, "name"))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, team)
+}
+
+// UpdateTeam меняет настройки команды; сейчас это только assignment_strategy
+func (h *Handler) UpdateTeam(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		AssignmentStrategy *string `json:"assignment_strategy"`
+	}
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	if req.AssignmentStrategy == nil {
+		h.writeError(w, "INVALID_REQUEST", "assignment_strategy is required", http.StatusBadRequest)
+		return
+	}
+
+	team, err := h.service.SetTeamStrategy(r.Context(), pathParam(r, "name"), *req.AssignmentStrategy)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, team)
+}
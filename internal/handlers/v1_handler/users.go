@@ -0,0 +1,185 @@
+package v1handler
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"prmanager/internal/handlers/params"
+	"prmanager/internal/models"
+	"time"
+)
+
+// maxCalendarSize - ограничение на размер загружаемого .ics
+const maxCalendarSize = 10 << 20
+
+// UpdateUser - частичное обновление пользователя. Поля применяются все вместе
+// или ни одно, в ответе - пользователь после изменения. max_open_reviews: null
+// снимает лимит, отсутствие поля оставляет его как есть.
+func (h *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		IsActive       *bool           `json:"is_active"`
+		Expertise      *[]string       `json:"expertise"`
+		MaxOpenReviews json.RawMessage `json:"max_open_reviews"`
+		ReviewWeight   *float64        `json:"review_weight"`
+		IsTrainee      *bool           `json:"is_trainee"`
+	}
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	update := models.UserUpdate{
+		IsActive:     req.IsActive,
+		Expertise:    req.Expertise,
+		ReviewWeight: req.ReviewWeight,
+		IsTrainee:    req.IsTrainee,
+	}
+	if len(req.MaxOpenReviews) > 0 {
+		if err := json.Unmarshal(req.MaxOpenReviews, &update.MaxOpenReviews); err != nil {
+			h.writeError(w, "INVALID_REQUEST", "max_open_reviews must be an integer or null", http.StatusBadRequest)
+			return
+		}
+		update.SetMaxOpenReviews = true
+	}
+
+	if update.Empty() {
+		h.writeError(w, "INVALID_REQUEST", "no fields to update", http.StatusBadRequest)
+		return
+	}
+
+	// Все поля проверяются до записи и пишутся одной транзакцией: ошибка в одном
+	// поле не оставляет пользователя измененным наполовину
+	user, err := h.service.UpdateUser(r.Context(), pathParam(r, "id"), update)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, user)
+}
+
+// GetUserReviews - страница PR, назначенных пользователю; параметры как у /users/getReview
+func (h *Handler) GetUserReviews(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	filter, err := params.ReviewFilter(query)
+	if err != nil {
+		h.writeError(w, "INVALID_REQUEST", err.Error(), http.StatusBadRequest)
+		return
+	}
+	filter.ReviewerID = pathParam(r, "id")
+
+	page, err := h.service.GetUserReviews(r.Context(), filter, query.Get("cursor"))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"pull_requests": page.PullRequests,
+		"next_cursor":   page.NextCursor,
+	})
+}
+
+func (h *Handler) GetOutOfOffice(w http.ResponseWriter, r *http.Request) {
+	periods, err := h.service.GetOutOfOffice(r.Context(), pathParam(r, "id"))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	if periods == nil {
+		periods = []*models.OutOfOffice{}
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"out_of_office": periods,
+	})
+}
+
+func (h *Handler) AddOutOfOffice(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		StartsAt time.Time `json:"starts_at"`
+		EndsAt   time.Time `json:"ends_at"`
+		Reason   string    `json:"reason"`
+	}
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	ooo, err := h.service.AddOutOfOffice(r.Context(), &models.OutOfOffice{
+		UserID:   pathParam(r, "id"),
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, ooo)
+}
+
+func (h *Handler) RemoveOutOfOffice(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.RemoveOutOfOffice(r.Context(), pathParam(r, "id")); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetAwayUsers - кто отсутствует в дату date (YYYY-MM-DD, UTC), по умолчанию сегодня
+func (h *Handler) GetAwayUsers(w http.ResponseWriter, r *http.Request) {
+	date := time.Now().UTC()
+	if value := r.URL.Query().Get("date"); value != "" {
+		parsed, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			h.writeError(w, "INVALID_REQUEST", "date must be in YYYY-MM-DD format", http.StatusBadRequest)
+			return
+		}
+		date = parsed
+	}
+
+	users, err := h.service.GetAwayUsers(r.Context(), date)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	if users == nil {
+		users = []*models.AwayUser{}
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"date":  date.Format(time.DateOnly),
+		"users": users,
+	})
+}
+
+// ImportCalendar принимает .ics в теле запроса (text/calendar)
+// или в поле file формы multipart/form-data
+func (h *Handler) ImportCalendar(w http.ResponseWriter, r *http.Request) {
+	var body io.Reader = http.MaxBytesReader(w, r.Body, maxCalendarSize)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		r.Body = http.MaxBytesReader(w, r.Body, maxCalendarSize)
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			h.writeError(w, "INVALID_REQUEST", "file is required", http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		body = file
+	}
+
+	result, err := h.service.ImportCalendar(r.Context(), body)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, result)
+}
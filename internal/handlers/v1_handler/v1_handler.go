@@ -0,0 +1,147 @@
+// Package v1handler - ресурсный API /api/v1 поверх того же сервиса,
+// что и старые RPC-маршруты (/team/add, /pullRequest/merge, ...).
+package v1handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"prmanager/internal/handlers/interfaces"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service interfaces.Service
+	logger  *log.Logger
+}
+
+func NewHandler(service interfaces.Service, logger *log.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Routes регистрирует маршруты /api/v1 (монтируется через router.Route)
+func (h *Handler) Routes(r chi.Router) {
+	r.Post("/teams", h.CreateTeam)
+	r.Get("/teams/{name}", h.GetTeam)
+	r.Patch("/teams/{name}", h.UpdateTeam)
+
+	r.Patch("/users/{id}", h.UpdateUser)
+	r.Get("/users/{id}/reviews", h.GetUserReviews)
+	r.Get("/users/{id}/out-of-office", h.GetOutOfOffice)
+	r.Post("/users/{id}/out-of-office", h.AddOutOfOffice)
+	r.Delete("/out-of-office/{id}", h.RemoveOutOfOffice)
+	r.Get("/away-users", h.GetAwayUsers)
+	r.Post("/calendar-imports", h.ImportCalendar)
+
+	r.Post("/pull-requests", h.CreatePullRequest)
+	r.Get("/pull-requests", h.ListPullRequests)
+	r.Get("/pull-requests/{id}", h.GetPullRequest)
+	r.Post("/pull-requests/{id}/merge", h.MergePullRequest)
+	r.Post("/pull-requests/{id}/reassign", h.ReassignReviewer)
+	r.Get("/pull-requests/{id}/assignments", h.GetAssignmentExplanations)
+	r.Get("/pending-assignments", h.GetPendingAssignments)
+	r.Post("/pull-request-imports", h.ImportPullRequests)
+	r.Post("/assignment-previews", h.PreviewAssignment)
+
+	r.Post("/repositories", h.CreateRepository)
+	r.Get("/repositories", h.ListRepositories)
+	r.Get("/repositories/{name}", h.GetRepository)
+	r.Patch("/repositories/{name}", h.UpdateRepository)
+	r.Delete("/repositories/{name}", h.DeleteRepository)
+	r.Get("/repositories/{name}/codeowners", h.GetCodeowners)
+	r.Put("/repositories/{name}/codeowners", h.PutCodeowners)
+
+	r.Post("/assignment-rules", h.CreateRule)
+	r.Get("/assignment-rules", h.ListRules)
+	r.Delete("/assignment-rules/{id}", h.DeleteRule)
+}
+
+// pathParam - параметр пути без URL-кодирования: id PR "repo#42" приходит
+// как repo%2342, имя репозитория "org/app" - как org%2Fapp
+func pathParam(r *http.Request, name string) string {
+	value := chi.URLParam(r, name)
+	if unescaped, err := url.PathUnescape(value); err == nil {
+		return unescaped
+	}
+	return value
+}
+
+func (h *Handler) decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		h.writeError(w, "INVALID_REQUEST", "Invalid request body", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func (h *Handler) writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// serviceErrors - HTTP-статус и сообщение для кодов ошибок сервиса
+var serviceErrors = map[string]struct {
+	status  int
+	message string
+}{
+	"INVALID_REQUEST":      {http.StatusBadRequest, "invalid request"},
+	"INVALID_CURSOR":       {http.StatusBadRequest, "cursor is invalid or does not match the query"},
+	"INVALID_STRATEGY":     {http.StatusUnprocessableEntity, "assignment_strategy must be random or round_robin"},
+	"INVALID_PERIOD":       {http.StatusUnprocessableEntity, "ends_at must be after starts_at"},
+	"INVALID_RULE":         {http.StatusUnprocessableEntity, "kind must be EXCLUDE or PREFER and reviewer must differ from author"},
+	"INVALID_CODEOWNERS":   {http.StatusUnprocessableEntity, "CODEOWNERS file could not be parsed"},
+	"INVALID_CALENDAR":     {http.StatusUnprocessableEntity, "calendar could not be parsed"},
+	"NOT_FOUND":            {http.StatusNotFound, "resource not found"},
+	"TEAM_NOT_FOUND":       {http.StatusNotFound, "team not found"},
+	"AUTHOR_NOT_FOUND":     {http.StatusNotFound, "author not found"},
+	"REPOSITORY_NOT_FOUND": {http.StatusNotFound, "repository not found"},
+	"TEAM_EXISTS":          {http.StatusConflict, "team already exists"},
+	"PR_EXISTS":            {http.StatusConflict, "pull request already exists"},
+	"REPOSITORY_EXISTS":    {http.StatusConflict, "repository already exists"},
+	"RULE_EXISTS":          {http.StatusConflict, "rule already exists"},
+	"REPOSITORY_IN_USE":    {http.StatusConflict, "repository has pull requests"},
+	"PR_MERGED":            {http.StatusConflict, "pull request is merged"},
+	"NOT_ASSIGNED":         {http.StatusConflict, "reviewer is not assigned to this pull request"},
+	"NO_CANDIDATE":         {http.StatusConflict, "no active replacement candidate in team"},
+}
+
+// handleError отвечает по коду ошибки сервиса; неизвестные ошибки - 500.
+// Если ошибка несет свою причину (Message), она заменяет общий текст кода
+func (h *Handler) handleError(w http.ResponseWriter, err error) {
+	code := err.Error()
+	mapped, ok := serviceErrors[code]
+	if !ok {
+		h.logger.Printf("API v1 internal error: %v", err)
+		h.writeError(w, "INTERNAL_ERROR", "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	message := mapped.message
+	var detailed interface{ Message() string }
+	if errors.As(err, &detailed) {
+		message = detailed.Message()
+	}
+	h.writeError(w, code, message, mapped.status)
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, code, message string, status int) {
+	h.logger.Printf("API v1 Error: %s - %s (status: %d)", code, message, status)
+
+	errorResp := struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}{}
+	errorResp.Error.Code = code
+	errorResp.Error.Message = message
+
+	h.writeJSON(w, status, errorResp)
+}
@@ -0,0 +1,341 @@
+// Package ical разбирает события (VEVENT) из файлов iCalendar (RFC 5545).
+// Поддерживается подмножество формата, достаточное для календарей отпусков:
+// свертка строк, параметры свойств, даты в UTC, с TZID (IANA или имя Windows) и "весь день".
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Person - ORGANIZER или ATTENDEE события
+type Person struct {
+	Name  string // параметр CN
+	Email string // адрес из mailto:
+}
+
+type Event struct {
+	UID        string
+	Summary    string
+	Categories []string
+	Status     string
+	Start      time.Time
+	End        time.Time
+	AllDay     bool
+	Recurring  bool
+	// UnknownTZID - TZID начала или окончания, который не удалось распознать;
+	// время такого события неизвестно, и его нельзя импортировать
+	UnknownTZID string
+	Organizer   *Person
+	Attendees   []Person
+	// Properties - все свойства события в исходном виде (первое значение)
+	Properties map[string]string
+}
+
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Parse читает все VEVENT из календаря
+func Parse(r io.Reader) ([]*Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events  []*Event
+		current *Event
+		depth   int // вложенность компонентов внутри VEVENT (VALARM и т.п.)
+	)
+
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		prop, err := parseProperty(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		switch {
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VEVENT") && current == nil:
+			current = &Event{Properties: make(map[string]string)}
+		case prop.name == "BEGIN" && current != nil:
+			depth++
+		case prop.name == "END" && current != nil && depth > 0:
+			depth--
+		case prop.name == "END" && strings.EqualFold(prop.value, "VEVENT") && current != nil:
+			if err := current.finish(); err != nil {
+				return nil, fmt.Errorf("event %q: %w", current.UID, err)
+			}
+			events = append(events, current)
+			current = nil
+		case current != nil && depth == 0:
+			if err := current.apply(prop); err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+		}
+	}
+
+	if current != nil {
+		return nil, fmt.Errorf("unterminated VEVENT %q", current.UID)
+	}
+
+	return events, nil
+}
+
+func (e *Event) apply(prop property) error {
+	if _, ok := e.Properties[prop.name]; !ok {
+		e.Properties[prop.name] = prop.value
+	}
+
+	switch prop.name {
+	case "UID":
+		e.UID = prop.value
+	case "SUMMARY":
+		e.Summary = unescape(prop.value)
+	case "STATUS":
+		e.Status = strings.ToUpper(prop.value)
+	case "CATEGORIES":
+		for _, category := range splitList(prop.value) {
+			e.Categories = append(e.Categories, unescape(category))
+		}
+	case "RRULE", "RDATE":
+		e.Recurring = true
+	case "ORGANIZER":
+		person := parsePerson(prop)
+		e.Organizer = &person
+	case "ATTENDEE":
+		e.Attendees = append(e.Attendees, parsePerson(prop))
+	case "DTSTART":
+		start, allDay, err := parseTime(prop)
+		if err != nil && !errors.Is(err, errUnknownTZID) {
+			return fmt.Errorf("DTSTART: %w", err)
+		}
+		if err != nil {
+			e.UnknownTZID = prop.params["TZID"]
+		}
+		e.Start, e.AllDay = start, allDay
+	case "DTEND":
+		end, _, err := parseTime(prop)
+		if err != nil && !errors.Is(err, errUnknownTZID) {
+			return fmt.Errorf("DTEND: %w", err)
+		}
+		if err != nil {
+			e.UnknownTZID = prop.params["TZID"]
+		}
+		e.End = end
+	}
+
+	return nil
+}
+
+// finish проверяет событие и вычисляет окончание, если DTEND не задан
+func (e *Event) finish() error {
+	if e.UID == "" {
+		return fmt.Errorf("missing UID")
+	}
+	if e.Start.IsZero() {
+		return fmt.Errorf("missing DTSTART")
+	}
+
+	if e.End.IsZero() {
+		if value, ok := e.Properties["DURATION"]; ok {
+			duration, err := parseDuration(value)
+			if err != nil {
+				return fmt.Errorf("DURATION: %w", err)
+			}
+			e.End = e.Start.Add(duration)
+		} else if e.AllDay {
+			e.End = e.Start.AddDate(0, 0, 1)
+		} else {
+			e.End = e.Start
+		}
+	}
+
+	return nil
+}
+
+// unfold склеивает строки, перенесенные по RFC 5545 (продолжение начинается с пробела/таба)
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read calendar: %w", err)
+	}
+
+	return lines, nil
+}
+
+// parseProperty разбирает строку вида NAME;PARAM=value;PARAM="v:x":VALUE
+func parseProperty(line string) (property, error) {
+	prop := property{params: make(map[string]string)}
+
+	inQuotes := false
+	nameEnd, valueStart := -1, -1
+	for i := 0; i < len(line) && valueStart < 0; i++ {
+		switch line[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case ';':
+			if !inQuotes && nameEnd < 0 {
+				nameEnd = i
+			}
+		case ':':
+			if !inQuotes {
+				valueStart = i + 1
+				if nameEnd < 0 {
+					nameEnd = i
+				}
+			}
+		}
+	}
+	if valueStart < 0 {
+		return prop, fmt.Errorf("malformed property %q", line)
+	}
+
+	prop.name = strings.ToUpper(line[:nameEnd])
+	prop.value = line[valueStart:]
+
+	if nameEnd < valueStart-1 {
+		for _, param := range splitParams(line[nameEnd+1 : valueStart-1]) {
+			key, value, _ := strings.Cut(param, "=")
+			prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+		}
+	}
+
+	return prop, nil
+}
+
+func splitParams(s string) []string {
+	var (
+		params   []string
+		start    int
+		inQuotes bool
+	)
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case ';':
+			if !inQuotes {
+				params = append(params, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(params, s[start:])
+}
+
+// splitList делит значение по неэкранированным запятым
+func splitList(s string) []string {
+	var (
+		items []string
+		start int
+	)
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] == ',' {
+			items = append(items, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	return append(items, strings.TrimSpace(s[start:]))
+}
+
+func unescape(s string) string {
+	replacer := strings.NewReplacer(`\\`, `\`, `\;`, `;`, `\,`, `,`, `\n`, "\n", `\N`, "\n")
+	return replacer.Replace(s)
+}
+
+func parsePerson(prop property) Person {
+	person := Person{Name: prop.params["CN"]}
+	if strings.HasPrefix(strings.ToLower(prop.value), "mailto:") {
+		person.Email = prop.value[len("mailto:"):]
+	}
+	return person
+}
+
+// errUnknownTZID - время задано в неизвестном часовом поясе. Событие при этом
+// разбирается (время считается в UTC), чтобы остальной календарь не пропадал.
+var errUnknownTZID = errors.New("unknown TZID")
+
+func parseTime(prop property) (time.Time, bool, error) {
+	value := prop.value
+
+	if strings.EqualFold(prop.params["VALUE"], "DATE") || len(value) == len("20060102") {
+		t, err := time.Parse("20060102", value)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+
+	loc, known := time.UTC, true
+	if tzid := prop.params["TZID"]; tzid != "" {
+		if tz, ok := loadLocation(tzid); ok {
+			loc = tz
+		} else {
+			known = false
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	if err == nil && !known {
+		err = fmt.Errorf("%w %q", errUnknownTZID, prop.params["TZID"])
+	}
+	return t.UTC(), false, err
+}
+
+var durationRe = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseDuration разбирает длительность вида P1W, P2DT4H, PT30M
+func parseDuration(value string) (time.Duration, error) {
+	m := durationRe.FindStringSubmatch(value)
+	if m == nil {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var total time.Duration
+	for i, unit := range units {
+		if m[i+2] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+2])
+		if err != nil {
+			return 0, err
+		}
+		total += time.Duration(n) * unit
+	}
+
+	if m[1] == "-" {
+		total = -total
+	}
+	return total, nil
+}
@@ -0,0 +1,60 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTimeZones(t *testing.T) {
+	tests := []struct {
+		name        string
+		dtstart     string
+		wantStart   time.Time
+		wantUnknown string
+	}{
+		{"utc", "DTSTART:20250310T090000Z", time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC), ""},
+		{"iana", "DTSTART;TZID=Europe/Moscow:20250310T090000", time.Date(2025, 3, 10, 6, 0, 0, 0, time.UTC), ""},
+		{"windows", `DTSTART;TZID="W. Europe Standard Time":20250310T090000`, time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC), ""},
+		{"floating", "DTSTART:20250310T090000", time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC), ""},
+		{"all day", "DTSTART;VALUE=DATE:20250310", time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), ""},
+		{"unknown", "DTSTART;TZID=Mars/Olympus_Mons:20250310T090000", time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC), "Mars/Olympus_Mons"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calendar := strings.Join([]string{
+				"BEGIN:VCALENDAR",
+				"BEGIN:VEVENT",
+				"UID:1",
+				tt.dtstart,
+				"END:VEVENT",
+				"END:VCALENDAR",
+			}, "\r\n")
+
+			events, err := Parse(strings.NewReader(calendar))
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if len(events) != 1 {
+				t.Fatalf("got %d events, want 1", len(events))
+			}
+
+			event := events[0]
+			if !event.Start.Equal(tt.wantStart) {
+				t.Errorf("Start = %v, want %v", event.Start, tt.wantStart)
+			}
+			if event.UnknownTZID != tt.wantUnknown {
+				t.Errorf("UnknownTZID = %q, want %q", event.UnknownTZID, tt.wantUnknown)
+			}
+		})
+	}
+}
+
+func TestWindowsZonesLoad(t *testing.T) {
+	for windows, iana := range windowsZones {
+		if _, err := time.LoadLocation(iana); err != nil {
+			t.Errorf("%s: %s: %v", windows, iana, err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package ical
+
+import (
+	"strings"
+	"time"
+
+	// Образ сервера (alpine) без системной базы часовых поясов
+	_ "time/tzdata"
+)
+
+// windowsZones - имена часовых поясов Windows (их пишет Outlook в TZID) и
+// соответствующие зоны IANA по таблице CLDR windowsZones (территория 001)
+var windowsZones = map[string]string{
+	"Dateline Standard Time":         "Etc/GMT+12",
+	"UTC-11":                         "Etc/GMT+11",
+	"Hawaiian Standard Time":         "Pacific/Honolulu",
+	"Alaskan Standard Time":          "America/Anchorage",
+	"Pacific Standard Time":          "America/Los_Angeles",
+	"US Mountain Standard Time":      "America/Phoenix",
+	"Mountain Standard Time":         "America/Denver",
+	"Central Standard Time":          "America/Chicago",
+	"Eastern Standard Time":          "America/New_York",
+	"Atlantic Standard Time":         "America/Halifax",
+	"E. South America Standard Time": "America/Sao_Paulo",
+	"UTC":                            "Etc/UTC",
+	"GMT Standard Time":              "Europe/London",
+	"Greenwich Standard Time":        "Atlantic/Reykjavik",
+	"W. Europe Standard Time":        "Europe/Berlin",
+	"Central Europe Standard Time":   "Europe/Budapest",
+	"Romance Standard Time":          "Europe/Paris",
+	"Central European Standard Time": "Europe/Warsaw",
+	"GTB Standard Time":              "Europe/Bucharest",
+	"FLE Standard Time":              "Europe/Kiev",
+	"E. Europe Standard Time":        "Europe/Chisinau",
+	"Kaliningrad Standard Time":      "Europe/Kaliningrad",
+	"Israel Standard Time":           "Asia/Jerusalem",
+	"Turkey Standard Time":           "Europe/Istanbul",
+	"Belarus Standard Time":          "Europe/Minsk",
+	"Russian Standard Time":          "Europe/Moscow",
+	"Samara Standard Time":           "Europe/Samara",
+	"Caucasus Standard Time":         "Asia/Yerevan",
+	"Georgian Standard Time":         "Asia/Tbilisi",
+	"Azerbaijan Standard Time":       "Asia/Baku",
+	"Arabian Standard Time":          "Asia/Dubai",
+	"Ekaterinburg Standard Time":     "Asia/Yekaterinburg",
+	"West Asia Standard Time":        "Asia/Tashkent",
+	"India Standard Time":            "Asia/Kolkata",
+	"Central Asia Standard Time":     "Asia/Almaty",
+	"Omsk Standard Time":             "Asia/Omsk",
+	"N. Central Asia Standard Time":  "Asia/Novosibirsk",
+	"SE Asia Standard Time":          "Asia/Bangkok",
+	"North Asia Standard Time":       "Asia/Krasnoyarsk",
+	"China Standard Time":            "Asia/Shanghai",
+	"Singapore Standard Time":        "Asia/Singapore",
+	"North Asia East Standard Time":  "Asia/Irkutsk",
+	"Tokyo Standard Time":            "Asia/Tokyo",
+	"Korea Standard Time":            "Asia/Seoul",
+	"Yakutsk Standard Time":          "Asia/Yakutsk",
+	"AUS Eastern Standard Time":      "Australia/Sydney",
+	"Vladivostok Standard Time":      "Asia/Vladivostok",
+	"Magadan Standard Time":          "Asia/Magadan",
+	"New Zealand Standard Time":      "Pacific/Auckland",
+}
+
+// loadLocation ищет TZID среди зон IANA, затем среди имен Windows
+func loadLocation(tzid string) (*time.Location, bool) {
+	tzid = strings.TrimSpace(tzid)
+
+	if loc, err := time.LoadLocation(tzid); err == nil {
+		return loc, true
+	}
+	if name, ok := windowsZones[tzid]; ok {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,248 @@
+// Package idempotency - повтор POST-запросов с заголовком Idempotency-Key.
+// Первый ответ сохраняется вместе с хешем запроса и отдается повторно на
+// дубликаты в течение TTL; тот же ключ с другим запросом отклоняется.
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"prmanager/internal/models"
+	"time"
+)
+
+const (
+	// HeaderKey - заголовок с ключом идемпотентности
+	HeaderKey = "Idempotency-Key"
+	// HeaderReplayed - признак ответа, взятого из сохраненных
+	HeaderReplayed = "Idempotent-Replayed"
+
+	// maxKeyLength - длина колонки idempotency_keys.key
+	maxKeyLength = 255
+	// maxBodySize - как у проверки по спецификации
+	maxBodySize = 10 << 20
+)
+
+// storedHeaders - заголовки ответа, которые повторяются вместе с телом
+var storedHeaders = []string{"Content-Type", "Location"}
+
+// Store - хранилище ключей (repository.Repository)
+type Store interface {
+	ReserveIdempotencyKey(ctx context.Context, key, requestHash string, ttl, lockTimeout time.Duration) (*models.IdempotencyRecord, bool, error)
+	TouchIdempotencyKey(ctx context.Context, key string) error
+	CompleteIdempotencyKey(ctx context.Context, key string, statusCode int, headers map[string]string, body []byte) error
+	ReleaseIdempotencyKey(ctx context.Context, key string) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+}
+
+type Middleware struct {
+	store Store
+	ttl   time.Duration
+	// lockTimeout - через сколько ключ, который перестали продлевать (упавшая реплика),
+	// можно занять снова
+	lockTimeout time.Duration
+	logger      *log.Logger
+}
+
+func NewMiddleware(store Store, ttl, lockTimeout time.Duration, logger *log.Logger) *Middleware {
+	return &Middleware{
+		store:       store,
+		ttl:         ttl,
+		lockTimeout: lockTimeout,
+		logger:      logger,
+	}
+}
+
+// Handler применяется к POST-запросам с Idempotency-Key; остальные проходят как есть.
+// Ответы 5xx не сохраняются: ключ освобождается, и запрос можно повторить.
+func (m *Middleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(HeaderKey)
+		if r.Method != http.MethodPost || key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if len(key) > maxKeyLength {
+			m.writeError(w, "INVALID_REQUEST", "Idempotency-Key must be at most 255 characters", http.StatusBadRequest)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+		if err != nil || len(body) > maxBodySize {
+			m.writeError(w, "INVALID_REQUEST", "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := r.Context()
+		hash := requestHash(r, body)
+
+		record, reserved, err := m.store.ReserveIdempotencyKey(ctx, key, hash, m.ttl, m.lockTimeout)
+		if err != nil {
+			m.logger.Printf("Idempotency key %q: %v", key, err)
+			m.writeError(w, "INTERNAL_ERROR", "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		if !reserved {
+			switch {
+			case record.RequestHash != hash:
+				m.writeError(w, "IDEMPOTENCY_KEY_REUSED", "Idempotency-Key was already used with a different request", http.StatusUnprocessableEntity)
+			case record.StatusCode == 0:
+				m.writeError(w, "IDEMPOTENCY_KEY_IN_PROGRESS", "a request with this Idempotency-Key is still in progress", http.StatusConflict)
+			default:
+				replay(w, record)
+			}
+			return
+		}
+
+		stopKeepLocked := m.keepLocked(ctx, key)
+		rec := &recorder{ResponseWriter: w}
+		defer func() {
+			stopKeepLocked()
+
+			// Ответ сохраняется и после обрыва соединения клиентом - ради этого ключ и нужен
+			storeCtx := context.WithoutCancel(ctx)
+			if rec.status == 0 || rec.status >= http.StatusInternalServerError {
+				if err := m.store.ReleaseIdempotencyKey(storeCtx, key); err != nil {
+					m.logger.Printf("Release idempotency key %q: %v", key, err)
+				}
+				return
+			}
+			if err := m.store.CompleteIdempotencyKey(storeCtx, key, rec.status, rec.headers, rec.body.Bytes()); err != nil {
+				m.logger.Printf("Store response for idempotency key %q: %v", key, err)
+			}
+		}()
+
+		next.ServeHTTP(rec, r)
+	})
+}
+
+// keepLocked продлевает блокировку ключа, пока выполняется запрос: иначе медленный
+// запрос (импорт PR, календарь) через lockTimeout считался бы зависшим, и повтор
+// с тем же ключом выполнился бы второй раз. Возвращает функцию остановки.
+func (m *Middleware) keepLocked(ctx context.Context, key string) func() {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(m.lockTimeout / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if err := m.store.TouchIdempotencyKey(ctx, key); err != nil && ctx.Err() == nil {
+				m.logger.Printf("Extend idempotency key %q: %v", key, err)
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// RunCleanup раз в interval удаляет ключи с истекшим сроком. Работает до отмены ctx.
+func (m *Middleware) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		deleted, err := m.store.DeleteExpiredIdempotencyKeys(ctx)
+		if err != nil {
+			m.logger.Printf("Delete expired idempotency keys: %v", err)
+			continue
+		}
+		if deleted > 0 {
+			m.logger.Printf("Deleted %d expired idempotency keys", deleted)
+		}
+	}
+}
+
+// requestHash - sha256 метода, пути с параметрами и тела
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.RequestURI()+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func replay(w http.ResponseWriter, record *models.IdempotencyRecord) {
+	for name, value := range record.Headers {
+		w.Header().Set(name, value)
+	}
+	w.Header().Set(HeaderReplayed, "true")
+	w.WriteHeader(record.StatusCode)
+	w.Write(record.Body)
+}
+
+// recorder передает ответ клиенту и запоминает его для сохранения
+type recorder struct {
+	http.ResponseWriter
+	status  int
+	headers map[string]string
+	body    bytes.Buffer
+}
+
+func (r *recorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+		r.headers = make(map[string]string)
+		for _, name := range storedHeaders {
+			if value := r.Header().Get(name); value != "" {
+				r.headers[name] = value
+			}
+		}
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *recorder) Write(data []byte) (int, error) {
+	if r.status == 0 {
+		r.WriteHeader(http.StatusOK)
+	}
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *recorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+func (m *Middleware) writeError(w http.ResponseWriter, code, message string, status int) {
+	m.logger.Printf("Idempotency Error: %s - %s (status: %d)", code, message, status)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	errorResp := struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}{}
+	errorResp.Error.Code = code
+	errorResp.Error.Message = message
+
+	json.NewEncoder(w).Encode(errorResp)
+}
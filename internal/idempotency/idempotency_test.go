@@ -0,0 +1,260 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"prmanager/internal/models"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// memoryStore - Store в памяти; ключ занят, пока запись существует
+type memoryStore struct {
+	mu       sync.Mutex
+	records  map[string]*models.IdempotencyRecord
+	touches  map[string]int
+	released map[string]int
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		records:  make(map[string]*models.IdempotencyRecord),
+		touches:  make(map[string]int),
+		released: make(map[string]int),
+	}
+}
+
+func (s *memoryStore) ReserveIdempotencyKey(_ context.Context, key, requestHash string, ttl, _ time.Duration) (*models.IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[key]; ok {
+		stored := *record
+		return &stored, false, nil
+	}
+
+	now := time.Now()
+	s.records[key] = &models.IdempotencyRecord{Key: key, RequestHash: requestHash, CreatedAt: now, ExpiresAt: now.Add(ttl)}
+	return nil, true, nil
+}
+
+func (s *memoryStore) TouchIdempotencyKey(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.touches[key]++
+	return nil
+}
+
+func (s *memoryStore) CompleteIdempotencyKey(_ context.Context, key string, statusCode int, headers map[string]string, body []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record := s.records[key]
+	record.StatusCode = statusCode
+	record.Headers = headers
+	record.Body = append([]byte(nil), body...)
+	return nil
+}
+
+func (s *memoryStore) ReleaseIdempotencyKey(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	s.released[key]++
+	return nil
+}
+
+func (s *memoryStore) DeleteExpiredIdempotencyKeys(context.Context) (int64, error) {
+	return 0, nil
+}
+
+func (s *memoryStore) touchCount(key string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.touches[key]
+}
+
+func newTestMiddleware(store Store, lockTimeout time.Duration) *Middleware {
+	return NewMiddleware(store, time.Hour, lockTimeout, log.New(io.Discard, "", 0))
+}
+
+func post(handler http.Handler, key, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, "/pullRequest/create", strings.NewReader(body))
+	if key != "" {
+		r.Header.Set(HeaderKey, key)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w
+}
+
+func errorCode(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	var resp struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode error response %q: %v", w.Body.String(), err)
+	}
+	return resp.Error.Code
+}
+
+func TestHandlerReplay(t *testing.T) {
+	var calls atomic.Int32
+	handler := newTestMiddleware(newMemoryStore(), time.Minute).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", "/api/v1/pull-requests/pr-1")
+		w.Header().Set("X-Request-Id", "not stored")
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, `{"pr":"pr-1"}`)
+	}))
+
+	first := post(handler, "key-1", `{"pull_request_id":"pr-1"}`)
+	second := post(handler, "key-1", `{"pull_request_id":"pr-1"}`)
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("handler called %d times, want 1", got)
+	}
+	if first.Header().Get(HeaderReplayed) != "" {
+		t.Errorf("first response marked as replayed")
+	}
+	if second.Code != http.StatusCreated || second.Body.String() != `{"pr":"pr-1"}` {
+		t.Errorf("replay = %d %s, want 201 with the first body", second.Code, second.Body.String())
+	}
+	if second.Header().Get(HeaderReplayed) != "true" {
+		t.Errorf("%s = %q, want true", HeaderReplayed, second.Header().Get(HeaderReplayed))
+	}
+	if got := second.Header().Get("Location"); got != "/api/v1/pull-requests/pr-1" {
+		t.Errorf("Location = %q, want the stored one", got)
+	}
+	if got := second.Header().Get("X-Request-Id"); got != "" {
+		t.Errorf("X-Request-Id = %q, want it not replayed", got)
+	}
+
+	// Без ключа запрос выполняется каждый раз
+	post(handler, "", `{"pull_request_id":"pr-1"}`)
+	if got := calls.Load(); got != 2 {
+		t.Errorf("handler called %d times after a request without key, want 2", got)
+	}
+}
+
+func TestHandlerKeyReusedWithDifferentRequest(t *testing.T) {
+	var calls atomic.Int32
+	handler := newTestMiddleware(newMemoryStore(), time.Minute).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	post(handler, "key-1", `{"pull_request_id":"pr-1"}`)
+	w := post(handler, "key-1", `{"pull_request_id":"pr-2"}`)
+
+	if w.Code != http.StatusUnprocessableEntity || errorCode(t, w) != "IDEMPOTENCY_KEY_REUSED" {
+		t.Errorf("response = %d %s, want 422 IDEMPOTENCY_KEY_REUSED", w.Code, w.Body.String())
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("handler called %d times, want 1", got)
+	}
+}
+
+func TestHandlerKeyInProgress(t *testing.T) {
+	entered := make(chan struct{})
+	finish := make(chan struct{})
+	handler := newTestMiddleware(newMemoryStore(), time.Minute).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(entered)
+		<-finish
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		done <- post(handler, "key-1", `{}`)
+	}()
+	<-entered
+
+	w := post(handler, "key-1", `{}`)
+	if w.Code != http.StatusConflict || errorCode(t, w) != "IDEMPOTENCY_KEY_IN_PROGRESS" {
+		t.Errorf("response = %d %s, want 409 IDEMPOTENCY_KEY_IN_PROGRESS", w.Code, w.Body.String())
+	}
+
+	close(finish)
+	if first := <-done; first.Code != http.StatusCreated {
+		t.Errorf("first response = %d, want 201", first.Code)
+	}
+}
+
+func TestHandlerReleasesKeyOnServerError(t *testing.T) {
+	store := newMemoryStore()
+	var calls atomic.Int32
+	handler := newTestMiddleware(store, time.Minute).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	if w := post(handler, "key-1", `{}`); w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("first response = %d, want 503", w.Code)
+	}
+	if store.released["key-1"] != 1 {
+		t.Errorf("key released %d times after 503, want 1", store.released["key-1"])
+	}
+
+	// Повтор выполняется заново, а его успешный ответ уже сохраняется
+	if w := post(handler, "key-1", `{}`); w.Code != http.StatusCreated || w.Header().Get(HeaderReplayed) != "" {
+		t.Errorf("retry = %d replayed=%q, want a fresh 201", w.Code, w.Header().Get(HeaderReplayed))
+	}
+	if w := post(handler, "key-1", `{}`); w.Code != http.StatusCreated || w.Header().Get(HeaderReplayed) != "true" {
+		t.Errorf("third request = %d replayed=%q, want a replayed 201", w.Code, w.Header().Get(HeaderReplayed))
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("handler called %d times, want 2", got)
+	}
+}
+
+func TestHandlerKeepsKeyLocked(t *testing.T) {
+	store := newMemoryStore()
+	const lockTimeout = 30 * time.Millisecond
+	handler := newTestMiddleware(store, lockTimeout).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Запрос длиннее lockTimeout: ключ продлевается раз в lockTimeout/3
+		time.Sleep(4 * lockTimeout)
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	post(handler, "key-1", `{}`)
+
+	touches := store.touchCount("key-1")
+	if touches < 3 {
+		t.Errorf("key extended %d times during a request of 4 lock timeouts, want at least 3", touches)
+	}
+
+	time.Sleep(2 * lockTimeout)
+	if got := store.touchCount("key-1"); got != touches {
+		t.Errorf("key extended %d more times after the request finished", got-touches)
+	}
+}
+
+func TestHandlerRejectsLongKey(t *testing.T) {
+	handler := newTestMiddleware(newMemoryStore(), time.Minute).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("handler called with an invalid key")
+	}))
+
+	w := post(handler, strings.Repeat("k", maxKeyLength+1), `{}`)
+	if w.Code != http.StatusBadRequest || errorCode(t, w) != "INVALID_REQUEST" {
+		t.Errorf("response = %d %s, want 400 INVALID_REQUEST", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,324 @@
+// Package migrate применяет встроенные миграции схемы. Версии хранятся в
+// таблице goose_db_version, как у goose, так что база, размеченная вручную
+// через migration.sh, продолжает мигрировать отсюда и наоборот.
+// Все операции выполняются под advisory-локом: реплики, стартующие
+// одновременно, применяют миграции по очереди, а не наперегонки.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	versionTable = "goose_db_version"
+	// lockQuery - сессионный лок, общий для всех реплик
+	lockQuery   = "SELECT pg_advisory_lock(hashtext('" + versionTable + "'))"
+	unlockQuery = "SELECT pg_advisory_unlock(hashtext('" + versionTable + "'))"
+
+	annotationUp   = "-- +goose Up"
+	annotationDown = "-- +goose Down"
+)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status - состояние миграции; AppliedAt == nil - еще не применена
+type Status struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+}
+
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+	logger     *log.Logger
+}
+
+// New читает миграции *.sql из fsys. Имя файла начинается с номера версии:
+// 001_init.sql.
+func New(pool *pgxpool.Pool, fsys fs.FS, logger *log.Logger) (*Migrator, error) {
+	migrations, err := load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		pool:       pool,
+		migrations: migrations,
+		logger:     logger,
+	}, nil
+}
+
+// Up применяет все непримененные миграции по возрастанию версии, каждую в своей
+// транзакции. Возвращает число примененных.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied := 0
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		versions, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := versions[migration.Version]; ok {
+				continue
+			}
+
+			m.logger.Printf("Applying migration %s", migration.Name)
+			if err := apply(ctx, conn, migration.Up,
+				"INSERT INTO "+versionTable+" (version_id, is_applied) VALUES ($1, true)", migration.Version); err != nil {
+				return fmt.Errorf("migration %s: %w", migration.Name, err)
+			}
+			applied++
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down откатывает последнюю примененную миграцию. Возвращает nil, если
+// откатывать нечего.
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	var rolledBack *Migration
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		versions, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := versions[migration.Version]; !ok {
+				continue
+			}
+
+			m.logger.Printf("Rolling back migration %s", migration.Name)
+			if err := apply(ctx, conn, migration.Down,
+				"DELETE FROM "+versionTable+" WHERE version_id = $1", migration.Version); err != nil {
+				return fmt.Errorf("migration %s: %w", migration.Name, err)
+			}
+			rolledBack = &migration
+			return nil
+		}
+		return nil
+	})
+	return rolledBack, err
+}
+
+// Status возвращает все встроенные миграции с временем применения
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		versions, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		statuses = make([]Status, 0, len(m.migrations))
+		for _, migration := range m.migrations {
+			status := Status{Version: migration.Version, Name: migration.Name}
+			if appliedAt, ok := versions[migration.Version]; ok {
+				status.AppliedAt = &appliedAt
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// withLock выполняет fn на одном соединении под сессионным advisory-локом
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, lockQuery); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer func() {
+		// Лок держится до конца сессии: если снять его не удалось,
+		// соединение закрывается, а не возвращается в пул
+		if _, err := conn.Exec(context.Background(), unlockQuery); err != nil {
+			m.logger.Printf("Failed to release migration lock: %v", err)
+			conn.Conn().Close(context.Background())
+		}
+	}()
+
+	if err := ensureVersionTable(ctx, conn); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+// ensureVersionTable создает таблицу версий так же, как goose
+func ensureVersionTable(ctx context.Context, conn *pgxpool.Conn) error {
+	var exists bool
+	if err := conn.QueryRow(ctx, "SELECT to_regclass($1) IS NOT NULL", versionTable).Scan(&exists); err != nil {
+		return fmt.Errorf("check %s: %w", versionTable, err)
+	}
+	if exists {
+		return nil
+	}
+
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `
+			CREATE TABLE `+versionTable+` (
+				id SERIAL PRIMARY KEY,
+				version_id BIGINT NOT NULL,
+				is_applied BOOLEAN NOT NULL,
+				tstamp TIMESTAMP NULL DEFAULT NOW()
+			)`); err != nil {
+			return fmt.Errorf("create %s: %w", versionTable, err)
+		}
+		if _, err := tx.Exec(ctx, "INSERT INTO "+versionTable+" (version_id, is_applied) VALUES (0, true)"); err != nil {
+			return fmt.Errorf("create %s: %w", versionTable, err)
+		}
+		return nil
+	})
+}
+
+// queryer - соединение, из которого читаются версии (*pgxpool.Conn)
+type queryer interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// appliedVersions - примененные версии и время применения; решает последняя
+// запись по версии
+func appliedVersions(ctx context.Context, conn queryer) (map[int64]time.Time, error) {
+	rows, err := conn.Query(ctx, `
+		SELECT version_id, is_applied, COALESCE(tstamp, NOW())
+		FROM `+versionTable+`
+		WHERE version_id > 0
+		ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", versionTable, err)
+	}
+	defer rows.Close()
+
+	versions := make(map[int64]time.Time)
+	for rows.Next() {
+		var (
+			version   int64
+			isApplied bool
+			tstamp    time.Time
+		)
+		if err := rows.Scan(&version, &isApplied, &tstamp); err != nil {
+			return nil, fmt.Errorf("read %s: %w", versionTable, err)
+		}
+		if isApplied {
+			versions[version] = tstamp
+		} else {
+			delete(versions, version)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", versionTable, err)
+	}
+	return versions, nil
+}
+
+// apply выполняет SQL миграции и запись о версии одной транзакцией.
+// SQL без параметров идет простым протоколом, поэтому в нем может быть
+// несколько выражений.
+func apply(ctx context.Context, conn *pgxpool.Conn, sql, record string, version int64) error {
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if strings.TrimSpace(sql) != "" {
+			if _, err := tx.Exec(ctx, sql); err != nil {
+				return err
+			}
+		}
+		_, err := tx.Exec(ctx, record, version)
+		return err
+	})
+}
+
+func load(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]Migration, 0, len(files))
+	seen := make(map[int64]string, len(files))
+	for _, file := range files {
+		prefix, _, _ := strings.Cut(path.Base(file), "_")
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: file name must start with a positive version", file)
+		}
+		if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf("migrations %s and %s have the same version %d", other, file, version)
+		}
+		seen[version] = file
+
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		up, down, err := parse(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: %w", file, err)
+		}
+
+		migrations = append(migrations, Migration{
+			Version: version,
+			Name:    strings.TrimSuffix(path.Base(file), ".sql"),
+			Up:      up,
+			Down:    down,
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// parse делит файл на секции Up и Down. Прочие аннотации goose
+// (StatementBegin/End) не нужны: секция выполняется целиком.
+func parse(data string) (string, string, error) {
+	var up, down strings.Builder
+	var section *strings.Builder
+
+	for _, line := range strings.SplitAfter(data, "\n") {
+		switch annotation := strings.TrimSpace(line); {
+		case annotation == annotationUp:
+			section = &up
+		case annotation == annotationDown:
+			section = &down
+		case annotation == "-- +goose StatementBegin", annotation == "-- +goose StatementEnd":
+		case strings.HasPrefix(annotation, "-- +goose"):
+			return "", "", fmt.Errorf("unsupported annotation %q", annotation)
+		case section != nil:
+			section.WriteString(line)
+		case annotation != "" && !strings.HasPrefix(annotation, "--"):
+			return "", "", errors.New("statement before " + annotationUp)
+		}
+	}
+
+	if strings.TrimSpace(up.String()) == "" {
+		return "", "", errors.New("missing " + annotationUp + " section")
+	}
+	return up.String(), down.String(), nil
+}
@@ -0,0 +1,228 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		wantUp   string
+		wantDown string
+		wantErr  string
+	}{
+		{
+			name:     "up and down",
+			data:     "-- +goose Up\nCREATE TABLE t (id INT);\n-- +goose Down\nDROP TABLE t;\n",
+			wantUp:   "CREATE TABLE t (id INT);\n",
+			wantDown: "DROP TABLE t;\n",
+		},
+		{
+			name: "statement blocks and header comments",
+			data: "-- adds a trigger\n\n-- +goose Up\n-- +goose StatementBegin\nCREATE FUNCTION f() RETURNS INT AS $$ SELECT 1; $$ LANGUAGE SQL;\n-- +goose StatementEnd\n" +
+				"-- +goose Down\nDROP FUNCTION f;",
+			wantUp:   "CREATE FUNCTION f() RETURNS INT AS $$ SELECT 1; $$ LANGUAGE SQL;\n",
+			wantDown: "DROP FUNCTION f;",
+		},
+		{
+			name:   "indented annotations, no down",
+			data:   "  -- +goose Up  \r\nSELECT 1;\r\n",
+			wantUp: "SELECT 1;\r\n",
+		},
+		{
+			name:    "missing up",
+			data:    "-- +goose Down\nDROP TABLE t;\n",
+			wantErr: "missing -- +goose Up section",
+		},
+		{
+			name:    "empty up",
+			data:    "-- +goose Up\n\n-- +goose Down\nDROP TABLE t;\n",
+			wantErr: "missing -- +goose Up section",
+		},
+		{
+			name:    "statement before up",
+			data:    "CREATE TABLE t (id INT);\n-- +goose Up\nSELECT 1;\n",
+			wantErr: "statement before -- +goose Up",
+		},
+		{
+			name:    "unsupported annotation",
+			data:    "-- +goose NO TRANSACTION\n-- +goose Up\nCREATE INDEX CONCURRENTLY i ON t (id);\n",
+			wantErr: `unsupported annotation "-- +goose NO TRANSACTION"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			up, down, err := parse(tt.data)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if up != tt.wantUp || down != tt.wantDown {
+				t.Errorf("parse = %q, %q, want %q, %q", up, down, tt.wantUp, tt.wantDown)
+			}
+		})
+	}
+}
+
+func sqlFile(up string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte("-- +goose Up\n" + up + "\n-- +goose Down\n")}
+}
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"010_search.sql":       sqlFile("SELECT 10;"),
+		"002_expertise.sql":    sqlFile("SELECT 2;"),
+		"001_init.sql":         sqlFile("SELECT 1;"),
+		"migrations.go":        &fstest.MapFile{Data: []byte("package migrations")},
+		"archive/001_old.sql":  sqlFile("SELECT 0;"),
+		"README.md":            &fstest.MapFile{Data: []byte("not a migration")},
+		"003_no_underscore.md": sqlFile("SELECT 3;"),
+	}
+
+	migrations, err := load(fsys)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	want := []Migration{
+		{Version: 1, Name: "001_init", Up: "SELECT 1;\n"},
+		{Version: 2, Name: "002_expertise", Up: "SELECT 2;\n"},
+		{Version: 10, Name: "010_search", Up: "SELECT 10;\n"},
+	}
+	if !reflect.DeepEqual(migrations, want) {
+		t.Errorf("migrations =\n%+v\nwant\n%+v", migrations, want)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		wantErr string
+	}{
+		{
+			name:    "duplicate version",
+			fsys:    fstest.MapFS{"001_init.sql": sqlFile("SELECT 1;"), "1_again.sql": sqlFile("SELECT 1;")},
+			wantErr: "have the same version 1",
+		},
+		{
+			name:    "no version",
+			fsys:    fstest.MapFS{"init.sql": sqlFile("SELECT 1;")},
+			wantErr: "migration init.sql: file name must start with a positive version",
+		},
+		{
+			name:    "zero version",
+			fsys:    fstest.MapFS{"000_base.sql": sqlFile("SELECT 1;")},
+			wantErr: "migration 000_base.sql: file name must start with a positive version",
+		},
+		{
+			name:    "negative version",
+			fsys:    fstest.MapFS{"-1_base.sql": sqlFile("SELECT 1;")},
+			wantErr: "file name must start with a positive version",
+		},
+		{
+			name:    "invalid file",
+			fsys:    fstest.MapFS{"001_init.sql": &fstest.MapFile{Data: []byte("SELECT 1;")}},
+			wantErr: "migration 001_init.sql: statement before -- +goose Up",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := load(tt.fsys)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// versionRow - строка goose_db_version
+type versionRow struct {
+	version   int64
+	isApplied bool
+	tstamp    time.Time
+}
+
+// fakeRows - pgx.Rows над заранее заданными строками
+type fakeRows struct {
+	pgx.Rows
+	rows []versionRow
+	next int
+}
+
+func (r *fakeRows) Next() bool {
+	r.next++
+	return r.next <= len(r.rows)
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	row := r.rows[r.next-1]
+	*dest[0].(*int64) = row.version
+	*dest[1].(*bool) = row.isApplied
+	*dest[2].(*time.Time) = row.tstamp
+	return nil
+}
+
+func (r *fakeRows) Close()                                       {}
+func (r *fakeRows) Err() error                                   { return nil }
+func (r *fakeRows) CommandTag() pgconn.CommandTag                { return pgconn.CommandTag{} }
+func (r *fakeRows) FieldDescriptions() []pgconn.FieldDescription { return nil }
+
+type fakeQueryer struct {
+	rows []versionRow
+	err  error
+}
+
+func (q fakeQueryer) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	return &fakeRows{rows: q.rows}, nil
+}
+
+func TestAppliedVersions(t *testing.T) {
+	at := func(minute int) time.Time {
+		return time.Date(2025, 3, 10, 9, minute, 0, 0, time.UTC)
+	}
+
+	// Строки в порядке id: откат goose пишет is_applied=false, повторное применение - новую строку
+	versions, err := appliedVersions(context.Background(), fakeQueryer{rows: []versionRow{
+		{1, true, at(1)},
+		{2, true, at(2)},
+		{3, true, at(3)},
+		{3, false, at(4)},
+		{4, false, at(5)},
+		{2, false, at(6)},
+		{2, true, at(7)},
+	}})
+	if err != nil {
+		t.Fatalf("appliedVersions: %v", err)
+	}
+
+	want := map[int64]time.Time{1: at(1), 2: at(7)}
+	if !reflect.DeepEqual(versions, want) {
+		t.Errorf("versions = %v, want %v", versions, want)
+	}
+
+	_, err = appliedVersions(context.Background(), fakeQueryer{err: errors.New("relation does not exist")})
+	if err == nil || !strings.Contains(err.Error(), "read goose_db_version") {
+		t.Errorf("err = %v, want a read error", err)
+	}
+}
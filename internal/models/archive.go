@@ -0,0 +1,78 @@
+package models
+
+import "time"
+
+// ArchiveVersion - версия формата архива; восстанавливается только та же версия
+const ArchiveVersion = 1
+
+// Archive - полная выгрузка данных сервиса для переноса между окружениями.
+// История (объяснения назначений, события, ключи идемпотентности) не выгружается.
+type Archive struct {
+	Version         int                  `json:"version"`
+	ExportedAt      time.Time            `json:"exported_at"`
+	Teams           []ArchiveTeam        `json:"teams"`
+	Users           []User               `json:"users"`
+	Repositories    []ArchiveRepository  `json:"repositories"`
+	PullRequests    []ArchivePullRequest `json:"pull_requests"`
+	OutOfOffice     []OutOfOffice        `json:"out_of_office"`
+	AssignmentRules []AssignmentRule     `json:"assignment_rules"`
+}
+
+// ArchiveTeam - команда с настройками назначения; участники - в Archive.Users
+type ArchiveTeam struct {
+	TeamName           string `json:"team_name"`
+	AssignmentStrategy string `json:"assignment_strategy"`
+	// RoundRobinLastUserID - позиция round_robin: последний назначенный пользователь
+	RoundRobinLastUserID string    `json:"round_robin_last_user_id,omitempty"`
+	CreatedAt            time.Time `json:"created_at"`
+}
+
+// ArchiveRepository - репозиторий вместе с его CODEOWNERS
+type ArchiveRepository struct {
+	Repository
+	Codeowners string `json:"codeowners,omitempty"`
+}
+
+// ArchivePullRequest - PR со временем назначения ревьюеров и состоянием очереди
+type ArchivePullRequest struct {
+	PullRequestID   string                  `json:"pull_request_id"`
+	PullRequestName string                  `json:"pull_request_name"`
+	AuthorID        string                  `json:"author_id"`
+	Status          string                  `json:"status"`
+	Repository      string                  `json:"repository,omitempty"`
+	Number          int                     `json:"number,omitempty"`
+	Files           []string                `json:"files,omitempty"`
+	Reviewers       []ArchiveReviewer       `json:"reviewers"`
+	ShadowReviewers []ArchiveShadowReviewer `json:"shadow_reviewers,omitempty"`
+	// PendingAssignment - запись очереди на добор ревьюеров, nil - PR не в очереди
+	PendingAssignment *ArchivePendingAssignment `json:"pending_assignment,omitempty"`
+	CreatedAt         time.Time                 `json:"createdAt"`
+	MergedAt          *time.Time                `json:"mergedAt,omitempty"`
+}
+
+type ArchiveReviewer struct {
+	UserID     string    `json:"user_id"`
+	AssignedAt time.Time `json:"assigned_at"`
+}
+
+type ArchiveShadowReviewer struct {
+	UserID     string    `json:"user_id"`
+	MentorID   string    `json:"mentor_id,omitempty"`
+	AssignedAt time.Time `json:"assigned_at"`
+}
+
+type ArchivePendingAssignment struct {
+	DesiredCount int       `json:"desired_count"`
+	Attempts     int       `json:"attempts"`
+	QueuedAt     time.Time `json:"queued_at"`
+}
+
+// ArchiveSummary - сколько записей выгружено или восстановлено
+type ArchiveSummary struct {
+	Teams           int `json:"teams"`
+	Users           int `json:"users"`
+	Repositories    int `json:"repositories"`
+	PullRequests    int `json:"pull_requests"`
+	OutOfOffice     int `json:"out_of_office"`
+	AssignmentRules int `json:"assignment_rules"`
+}
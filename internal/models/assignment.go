@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+const (
+	// RuleExclude - ревьюер никогда не назначается на PR автора
+	RuleExclude = "EXCLUDE"
+	// RulePrefer - ревьюер выбирается раньше остальных кандидатов автора
+	RulePrefer = "PREFER"
+)
+
+// AssignmentRule - правило подбора ревьюеров для PR конкретного автора
+type AssignmentRule struct {
+	ID         string    `json:"id"`
+	Kind       string    `json:"kind"`
+	AuthorID   string    `json:"author_id"`
+	ReviewerID string    `json:"reviewer_id"`
+	Reason     string    `json:"reason,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+const (
+	// События, при которых подбирались ревьюеры PR
+	AssignmentEventCreate   = "create"
+	AssignmentEventPending  = "pending"
+	AssignmentEventReassign = "reassign"
+)
+
+// CandidateDecision - решение по одному кандидату в ревьюеры и его причины
+type CandidateDecision struct {
+	UserID   string `json:"user_id"`
+	Accepted bool   `json:"accepted"`
+	// Selected - кандидат назначен ревьюером
+	Selected bool `json:"selected"`
+	// Rank - место среди допущенных кандидатов (1 - выбирается первым)
+	Rank    int      `json:"rank,omitempty"`
+	Reasons []string `json:"reasons"`
+}
+
+// AssignmentExplanation - как подбирались ревьюеры PR: кто рассматривался,
+// кто отброшен фильтрами и правилами и кто в итоге назначен
+type AssignmentExplanation struct {
+	PullRequestID     string              `json:"pull_request_id,omitempty"`
+	Event             string              `json:"event,omitempty"`
+	AuthorID          string              `json:"author_id"`
+	TeamName          string              `json:"team_name"`
+	Repository        string              `json:"repository,omitempty"`
+	Strategy          string              `json:"strategy,omitempty"`
+	ReviewerCount     int                 `json:"reviewer_count,omitempty"`
+	AssignedReviewers []string            `json:"assigned_reviewers"`
+	ShadowReviewers   []ShadowReviewer    `json:"shadow_reviewers,omitempty"`
+	Candidates        []CandidateDecision `json:"candidates"`
+	CreatedAt         *time.Time          `json:"created_at,omitempty"`
+}
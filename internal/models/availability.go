@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// OutOfOffice - период, в который пользователь недоступен для ревью
+type OutOfOffice struct {
+	ID       string    `json:"id"`
+	UserID   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason,omitempty"`
+	// ExternalUID - UID события календаря, из которого импортирован период
+	ExternalUID string `json:"external_uid,omitempty"`
+}
+
+// AwayUser - пользователь и его периоды отсутствия, пересекающие запрошенную дату
+type AwayUser struct {
+	User
+	Periods []OutOfOffice `json:"periods"`
+}
+
+// CalendarImportResult - итог импорта календаря отсутствий
+type CalendarImportResult struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Cancelled int `json:"cancelled"`
+	// Removed - периоды участников, которых убрали из события при повторном импорте
+	Removed int                  `json:"removed"`
+	Skipped []CalendarImportSkip `json:"skipped"`
+}
+
+type CalendarImportSkip struct {
+	UID    string `json:"uid"`
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,6 @@
+package models
+
+type Codeowners struct {
+	Repository string `json:"repository"`
+	Content    string `json:"content"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+const (
+	// Типы событий потока /events
+	EventPullRequestCreated = "pr.created"
+	EventReviewersAssigned  = "pr.assigned"
+	EventReviewerReassigned = "pr.reassigned"
+	EventPullRequestMerged  = "pr.merged"
+)
+
+// PullRequestEvent - событие PR; ID - позиция в потоке, по ней клиент продолжает чтение
+type PullRequestEvent struct {
+	ID            int64  `json:"id"`
+	Type          string `json:"type"`
+	PullRequestID string `json:"pull_request_id"`
+	AuthorID      string `json:"author_id"`
+	Repository    string `json:"repository,omitempty"`
+	Status        string `json:"status"`
+	// AssignedReviewers - ревьюеры PR после события
+	AssignedReviewers []string `json:"assigned_reviewers"`
+	// AddedReviewers - ревьюеры, назначенные этим событием
+	AddedReviewers []string `json:"added_reviewers,omitempty"`
+	// OldReviewerID и NewReviewerID - только для pr.reassigned
+	OldReviewerID string `json:"old_reviewer_id,omitempty"`
+	NewReviewerID string `json:"new_reviewer_id,omitempty"`
+	// Teams - команды автора и ревьюеров на момент события
+	Teams     []string  `json:"teams,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	// UserIDs - участники события (автор, ревьюеры, стажеры, снятый ревьюер), по ним фильтруется поток
+	UserIDs []string `json:"-"`
+}
+
+// EventFilter - события после AfterID, затрагивающие команду и/или пользователя
+// (автор или ревьюер); пустые поля не фильтруют
+type EventFilter struct {
+	TeamName string
+	UserID   string
+	AfterID  int64
+	Limit    int
+}
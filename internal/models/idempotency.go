@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// IdempotencyRecord - сохраненный ответ на запрос с Idempotency-Key
+type IdempotencyRecord struct {
+	Key         string
+	RequestHash string
+	// StatusCode - 0, пока первый запрос с ключом выполняется
+	StatusCode int
+	Headers    map[string]string
+	Body       []byte
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+const (
+	// Режимы пакетного импорта PR
+	ImportModeAtomic  = "atomic"
+	ImportModeChunked = "chunked"
+
+	// Итог импорта одного PR
+	ImportItemCreated = "created"
+	ImportItemFailed  = "failed"
+	// ImportItemSkipped - PR корректен, но не записан: в атомарном импорте ошибка в другом PR
+	ImportItemSkipped = "skipped"
+)
+
+// PullRequestImportItem - PR во входных данных импорта.
+// AssignedReviewers = nil - ревьюеры подбираются как при создании (для OPEN PR).
+type PullRequestImportItem struct {
+	PullRequestID     string     `json:"pull_request_id"`
+	PullRequestName   string     `json:"pull_request_name"`
+	AuthorID          string     `json:"author_id"`
+	Repository        string     `json:"repository"`
+	Number            int        `json:"number"`
+	Files             []string   `json:"files"`
+	Status            string     `json:"status"`
+	AssignedReviewers []string   `json:"assigned_reviewers"`
+	CreatedAt         *time.Time `json:"createdAt"`
+	MergedAt          *time.Time `json:"mergedAt"`
+}
+
+// PullRequestImportResult - итог импорта PR с индексом во входных данных
+type PullRequestImportResult struct {
+	Index             int      `json:"index"`
+	PullRequestID     string   `json:"pull_request_id,omitempty"`
+	Status            string   `json:"status"`
+	Error             string   `json:"error,omitempty"`
+	Message           string   `json:"message,omitempty"`
+	AssignedReviewers []string `json:"assigned_reviewers,omitempty"`
+	PendingAssignment bool     `json:"pending_assignment,omitempty"`
+}
+
+// PullRequestImportReport - итог пакетного импорта
+type PullRequestImportReport struct {
+	Mode    string                     `json:"mode"`
+	Total   int                        `json:"total"`
+	Created int                        `json:"created"`
+	Failed  int                        `json:"failed"`
+	Skipped int                        `json:"skipped"`
+	Results []*PullRequestImportResult `json:"results"`
+}
@@ -3,15 +3,28 @@ package models
 import "time"
 
 type PullRequest struct {
-	PullRequestID     string     `json:"pull_request_id"`
-	PullRequestName   string     `json:"pull_request_name"`
-	AuthorID          string     `json:"author_id"`
-	Status            string     `json:"status"`
-	AssignedReviewers []string   `json:"assigned_reviewers"`
+	PullRequestID     string   `json:"pull_request_id"`
+	PullRequestName   string   `json:"pull_request_name"`
+	AuthorID          string   `json:"author_id"`
+	Status            string   `json:"status"`
+	Repository        string   `json:"repository,omitempty"`
+	Number            int      `json:"number,omitempty"`
+	AssignedReviewers []string `json:"assigned_reviewers"`
+	// ShadowReviewers - стажеры-наблюдатели; не учитываются в числе ревьюеров
+	ShadowReviewers []ShadowReviewer `json:"shadow_reviewers,omitempty"`
+	Files           []string         `json:"files,omitempty"`
+	// PendingAssignment - PR ждет освобождения ревьюеров, чтобы добрать нужное количество
+	PendingAssignment bool       `json:"pending_assignment,omitempty"`
 	CreatedAt         time.Time  `json:"createdAt,omitempty"`
 	MergedAt          *time.Time `json:"mergedAt,omitempty"`
 }
 
+// ShadowReviewer - стажер, проходящий ревью вместе с наставником из назначенных ревьюеров
+type ShadowReviewer struct {
+	UserID   string `json:"user_id"`
+	MentorID string `json:"mentor_id,omitempty"`
+}
+
 type PullRequestShort struct {
 	PullRequestID   string `json:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name"`
@@ -23,3 +36,88 @@ type ReassignResult struct {
 	PR            *PullRequest `json:"pr"`
 	NewReviewerID string       `json:"replaced_by"`
 }
+
+// PendingAssignment - PR в очереди на добор ревьюеров
+type PendingAssignment struct {
+	PullRequestID string     `json:"pull_request_id"`
+	DesiredCount  int        `json:"desired_count"`
+	QueuedAt      time.Time  `json:"queued_at"`
+	Attempts      int        `json:"attempts"`
+	LastAttemptAt *time.Time `json:"last_attempt_at,omitempty"`
+}
+
+// PendingAssignmentFill - добор ревьюеров PR из очереди, подобранный по состоянию PR
+// на момент загрузки. Записывается, только если с тех пор PR не изменился.
+type PendingAssignmentFill struct {
+	PullRequestID string
+	// Reviewers - ревьюеры PR, от которых шел подбор
+	Reviewers []string
+	Added     []string
+	// Shadow - стажер; добавляется, только если у PR его еще нет
+	Shadow  *ShadowReviewer
+	Advance *RoundRobinAdvance
+	// Done - ревьюеров достаточно, PR выходит из очереди
+	Done bool
+	// Event - событие о доборе, пишется вместе с ним
+	Event *PullRequestEvent
+}
+
+// UnderstaffedPullRequest - открытый PR из очереди вместе с текущим числом ревьюеров
+type UnderstaffedPullRequest struct {
+	PullRequestShort
+	DesiredCount  int        `json:"desired_count"`
+	AssignedCount int        `json:"assigned_count"`
+	QueuedAt      time.Time  `json:"queued_at"`
+	Attempts      int        `json:"attempts"`
+	LastAttemptAt *time.Time `json:"last_attempt_at,omitempty"`
+}
+
+const (
+	// Поля сортировки списка PR
+	PullRequestSortCreated   = "created"
+	PullRequestSortMerged    = "merged"
+	PullRequestSortRelevance = "relevance"
+)
+
+// PullRequestFilter - фильтры, поиск, сортировка и страница списка PR.
+// Query - полнотекстовый поиск по названию (синтаксис websearch: "слова", -исключение, or).
+type PullRequestFilter struct {
+	Status      string
+	AuthorID    string
+	TeamName    string
+	ReviewerID  string
+	Repository  string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MergedFrom  *time.Time
+	MergedTo    *time.Time
+	Query       string
+	SortBy      string
+	Ascending   bool
+	Limit       int
+	// After - позиция последнего элемента предыдущей страницы
+	After *PullRequestCursor
+}
+
+// PullRequestCursor - ключ сортировки последнего элемента страницы и id PR.
+// Для сортировки по релевантности используется Rank, иначе Time.
+type PullRequestCursor struct {
+	SortBy    string     `json:"s"`
+	Ascending bool       `json:"a,omitempty"`
+	Query     string     `json:"q,omitempty"`
+	Time      *time.Time `json:"t,omitempty"`
+	Rank      *float64   `json:"r,omitempty"`
+	ID        string     `json:"id"`
+}
+
+// PullRequestListItem - PR в списке; Rank заполняется при поиске
+type PullRequestListItem struct {
+	PullRequest
+	Rank *float64 `json:"rank,omitempty"`
+}
+
+// PullRequestPage - страница списка PR; NextCursor пуст на последней странице
+type PullRequestPage struct {
+	PullRequests []*PullRequestListItem `json:"pull_requests"`
+	NextCursor   string                 `json:"next_cursor,omitempty"`
+}
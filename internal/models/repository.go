@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type Repository struct {
+	Name                 string    `json:"name"`
+	DefaultReviewerCount int       `json:"default_reviewer_count"`
+	TeamName             string    `json:"team_name,omitempty"`
+	CreatedAt            time.Time `json:"createdAt,omitempty"`
+}
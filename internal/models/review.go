@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+const (
+	// Поля сортировки списка ревью пользователя
+	ReviewSortCreated  = "created"
+	ReviewSortAssigned = "assigned"
+)
+
+// UserReview - PR в списке ревью пользователя
+type UserReview struct {
+	PullRequestShort
+	Repository string     `json:"repository,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	AssignedAt time.Time  `json:"assigned_at"`
+	MergedAt   *time.Time `json:"mergedAt,omitempty"`
+}
+
+// ReviewFilter - фильтры, сортировка и страница списка ревью пользователя.
+// From/To ограничивают поле сортировки: время создания PR или время назначения.
+type ReviewFilter struct {
+	ReviewerID string
+	Status     string
+	AuthorID   string
+	Repository string
+	From       *time.Time
+	To         *time.Time
+	SortBy     string
+	Ascending  bool
+	Limit      int
+	// After - позиция последнего элемента предыдущей страницы
+	After *ReviewCursor
+}
+
+// ReviewCursor - ключ (время сортировки, id PR) последнего элемента страницы
+type ReviewCursor struct {
+	SortBy    string    `json:"s"`
+	Ascending bool      `json:"a,omitempty"`
+	Time      time.Time `json:"t"`
+	ID        string    `json:"id"`
+}
+
+// ReviewPage - страница списка ревью; NextCursor пуст на последней странице
+type ReviewPage struct {
+	PullRequests []*UserReview `json:"pull_requests"`
+	NextCursor   string        `json:"next_cursor,omitempty"`
+}
@@ -1,6 +1,22 @@
 package models
 
+const (
+	StrategyRandom     = "random"
+	StrategyRoundRobin = "round_robin"
+)
+
 type Team struct {
 	TeamName string `json:"team_name"`
 	Members  []User `json:"members"`
+	// AssignmentStrategy - random (по умолчанию) или round_robin
+	AssignmentStrategy string `json:"assignment_strategy,omitempty"`
+}
+
+// RoundRobinAdvance - сдвиг курсора round_robin команды после выбора ревьюеров.
+// Записывается в одной транзакции с назначением: From - позиция, от которой
+// шел выбор (nil - с начала), To - последний выбранный пользователь.
+type RoundRobinAdvance struct {
+	TeamName string
+	From     *string
+	To       string
 }
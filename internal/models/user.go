@@ -5,4 +5,28 @@ type User struct {
 	Username string `json:"username"`
 	TeamName string `json:"team_name"`
 	IsActive bool   `json:"is_active"`
+	// MaxOpenReviews - лимит одновременных ревью открытых PR, nil - без ограничения
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty"`
+	// ReviewWeight - относительная вероятность быть выбранным ревьюером (по умолчанию 1)
+	ReviewWeight float64 `json:"review_weight"`
+	// IsTrainee - стажер: назначается только теневым ревьюером в пару к обычному
+	IsTrainee bool `json:"is_trainee"`
+	// Expertise - теги (например "go", "frontend") или шаблоны путей ("/internal/**")
+	Expertise []string `json:"expertise,omitempty"`
+}
+
+// UserUpdate - частичное изменение пользователя; nil-поля не меняются
+type UserUpdate struct {
+	IsActive  *bool
+	Expertise *[]string
+	// SetMaxOpenReviews - менять лимит; MaxOpenReviews == nil снимает ограничение
+	SetMaxOpenReviews bool
+	MaxOpenReviews    *int
+	ReviewWeight      *float64
+	IsTrainee         *bool
+}
+
+// Empty - в изменении нет ни одного поля
+func (u UserUpdate) Empty() bool {
+	return u.IsActive == nil && u.Expertise == nil && !u.SetMaxOpenReviews && u.ReviewWeight == nil && u.IsTrainee == nil
 }
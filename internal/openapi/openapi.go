@@ -0,0 +1,165 @@
+// Package openapi публикует спецификацию API (OpenAPI 3) и проверяет
+// входящие запросы по ней. Поддерживается подмножество JSON Schema, которое
+// используется в спецификации: типы, required, enum, длины строк, границы чисел,
+// форматы date-time/date/uuid, $ref и allOf.
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+//go:embed openapi.json
+var spec []byte
+
+// Spec возвращает спецификацию в исходном виде
+func Spec() []byte {
+	return spec
+}
+
+// Handler отдает спецификацию (GET /openapi.json)
+func Handler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(spec)
+}
+
+type Document struct {
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components struct {
+		Schemas    map[string]*Schema    `json:"schemas"`
+		Parameters map[string]*Parameter `json:"parameters"`
+	} `json:"components"`
+}
+
+type Operation struct {
+	Parameters  []*Parameter `json:"parameters"`
+	RequestBody *RequestBody `json:"requestBody"`
+}
+
+type Parameter struct {
+	Ref      string  `json:"$ref"`
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Ref        string             `json:"$ref"`
+	Type       string             `json:"type"`
+	Format     string             `json:"format"`
+	Nullable   bool               `json:"nullable"`
+	Enum       []any              `json:"enum"`
+	MinLength  *int               `json:"minLength"`
+	MaxLength  *int               `json:"maxLength"`
+	Minimum    *float64           `json:"minimum"`
+	Maximum    *float64           `json:"maximum"`
+	Required   []string           `json:"required"`
+	Properties map[string]*Schema `json:"properties"`
+	Items      *Schema            `json:"items"`
+	AllOf      []*Schema          `json:"allOf"`
+}
+
+// Load разбирает встроенную спецификацию и разрешает ссылки на параметры
+func Load() (*Document, error) {
+	var doc Document
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("parse openapi spec: %w", err)
+	}
+
+	for path, item := range doc.Paths {
+		for method, op := range item {
+			for i, param := range op.Parameters {
+				if param.Ref == "" {
+					continue
+				}
+				resolved, ok := doc.Components.Parameters[strings.TrimPrefix(param.Ref, "#/components/parameters/")]
+				if !ok {
+					return nil, fmt.Errorf("%s %s: unknown parameter %s", method, path, param.Ref)
+				}
+				op.Parameters[i] = resolved
+			}
+		}
+	}
+
+	for name, schema := range doc.Components.Schemas {
+		if err := doc.checkRefs(schema); err != nil {
+			return nil, fmt.Errorf("schema %s: %w", name, err)
+		}
+	}
+	for path, item := range doc.Paths {
+		for method, op := range item {
+			if err := doc.checkOperation(op); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+		}
+	}
+
+	return &doc, nil
+}
+
+func (d *Document) checkOperation(op *Operation) error {
+	for _, param := range op.Parameters {
+		if err := d.checkRefs(param.Schema); err != nil {
+			return err
+		}
+	}
+	if op.RequestBody != nil {
+		for _, media := range op.RequestBody.Content {
+			if err := d.checkRefs(media.Schema); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkRefs проверяет, что все $ref схемы указывают на существующие схемы
+func (d *Document) checkRefs(s *Schema) error {
+	if s == nil {
+		return nil
+	}
+	if s.Ref != "" {
+		if _, ok := d.Components.Schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]; !ok {
+			return fmt.Errorf("unknown schema %s", s.Ref)
+		}
+		return nil
+	}
+
+	children := append([]*Schema{s.Items}, s.AllOf...)
+	for _, property := range s.Properties {
+		children = append(children, property)
+	}
+	for _, child := range children {
+		if err := d.checkRefs(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HasOperation - описана ли операция method path (path - шаблон вида /teams/{name})
+func (d *Document) HasOperation(method, path string) bool {
+	_, ok := d.Paths[path][strings.ToLower(method)]
+	return ok
+}
+
+// schema возвращает схему, на которую указывает $ref (или саму схему)
+func (d *Document) schema(s *Schema) *Schema {
+	for s != nil && s.Ref != "" {
+		s = d.Components.Schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+	}
+	return s
+}
@@ -0,0 +1,403 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxBodySize - тела больше не проверяются и отклоняются
+const maxBodySize = 10 << 20
+
+var uuidRe = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// FieldError - поле запроса, не прошедшее проверку
+type FieldError struct {
+	Field   string `json:"field"`
+	In      string `json:"in"`
+	Message string `json:"message"`
+}
+
+// Validator проверяет запросы по спецификации
+type Validator struct {
+	doc    *Document
+	routes []route
+}
+
+type route struct {
+	segments []string
+	params   int
+	methods  map[string]*Operation
+}
+
+func NewValidator(doc *Document) *Validator {
+	v := &Validator{doc: doc}
+
+	for path, methods := range doc.Paths {
+		r := route{segments: strings.Split(strings.Trim(path, "/"), "/"), methods: make(map[string]*Operation)}
+		for _, segment := range r.segments {
+			if isTemplate(segment) {
+				r.params++
+			}
+		}
+		for method, op := range methods {
+			r.methods[strings.ToUpper(method)] = op
+		}
+		v.routes = append(v.routes, r)
+	}
+
+	// Буквальные пути важнее шаблонных: /teams/list раньше /teams/{name}
+	sort.Slice(v.routes, func(i, j int) bool {
+		return v.routes[i].params < v.routes[j].params
+	})
+
+	return v
+}
+
+// Middleware отклоняет запросы, не соответствующие спецификации, с кодом 400
+// и списком полей. Запросы к путям вне спецификации пропускаются как есть.
+func (v *Validator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		op, pathParams := v.match(r.Method, r.URL.EscapedPath())
+		if op == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		errs := v.validateParams(op, r, pathParams)
+
+		bodyErrs, err := v.validateBody(op, r)
+		if err != nil {
+			writeErrors(w, http.StatusRequestEntityTooLarge, "REQUEST_TOO_LARGE", err.Error(), nil)
+			return
+		}
+		errs = append(errs, bodyErrs...)
+
+		if len(errs) > 0 {
+			writeErrors(w, http.StatusBadRequest, "VALIDATION_ERROR", "request validation failed", errs)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// match ищет операцию по экранированному пути: закодированный "/" внутри
+// параметра (org%2Fapp) не делит путь на сегменты
+func (v *Validator) match(method, escapedPath string) (*Operation, map[string]string) {
+	segments := strings.Split(strings.Trim(escapedPath, "/"), "/")
+	for i, segment := range segments {
+		if unescaped, err := url.PathUnescape(segment); err == nil {
+			segments[i] = unescaped
+		}
+	}
+
+	for _, r := range v.routes {
+		if len(r.segments) != len(segments) {
+			continue
+		}
+
+		params := make(map[string]string)
+		matched := true
+		for i, segment := range r.segments {
+			if isTemplate(segment) {
+				params[segment[1:len(segment)-1]] = segments[i]
+			} else if segment != segments[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return r.methods[method], params
+		}
+	}
+
+	return nil, nil
+}
+
+func (v *Validator) validateParams(op *Operation, r *http.Request, pathParams map[string]string) []FieldError {
+	var errs []FieldError
+	query := r.URL.Query()
+
+	for _, param := range op.Parameters {
+		var (
+			value   string
+			present bool
+		)
+		switch param.In {
+		case "query":
+			present = query.Has(param.Name)
+			value = query.Get(param.Name)
+		case "path":
+			value, present = pathParams[param.Name]
+		default:
+			continue
+		}
+
+		if !present || value == "" {
+			if param.Required {
+				errs = append(errs, FieldError{Field: param.Name, In: param.In, Message: "is required"})
+			}
+			continue
+		}
+
+		if msg := v.checkParam(param.Schema, value); msg != "" {
+			errs = append(errs, FieldError{Field: param.Name, In: param.In, Message: msg})
+		}
+	}
+
+	return errs
+}
+
+// checkParam проверяет строковое значение параметра, приводя его к типу схемы
+func (v *Validator) checkParam(schema *Schema, value string) string {
+	schema = v.doc.schema(schema)
+	if schema == nil {
+		return ""
+	}
+
+	switch schema.Type {
+	case "integer":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return "must be an integer"
+		}
+		return checkNumber(schema, float64(n))
+	case "number":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "must be a number"
+		}
+		return checkNumber(schema, n)
+	case "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return "must be true or false"
+		}
+		return ""
+	default:
+		return checkString(schema, value)
+	}
+}
+
+func (v *Validator) validateBody(op *Operation, r *http.Request) ([]FieldError, error) {
+	if op.RequestBody == nil {
+		return nil, nil
+	}
+
+	media, ok := op.RequestBody.Content["application/json"]
+	if !ok {
+		return nil, nil
+	}
+
+	// Если операция принимает и другие типы (text/plain), тело без явного
+	// application/json разбирает сам обработчик
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "application/json" && len(op.RequestBody.Content) > 1 {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		return []FieldError{{In: "body", Message: "could not read request body"}}, nil
+	}
+	if len(data) > maxBodySize {
+		return nil, fmt.Errorf("request body exceeds %d bytes", maxBodySize)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(data))
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		if op.RequestBody.Required {
+			return []FieldError{{In: "body", Message: "request body is required"}}, nil
+		}
+		return nil, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return []FieldError{{In: "body", Message: "must be valid JSON"}}, nil
+	}
+
+	return v.validateValue("", media.Schema, value), nil
+}
+
+// validateValue проверяет значение тела по схеме; path - путь к полю (members[0].user_id)
+func (v *Validator) validateValue(path string, schema *Schema, value any) []FieldError {
+	schema = v.doc.schema(schema)
+	if schema == nil {
+		return nil
+	}
+
+	fail := func(msg string) []FieldError {
+		return []FieldError{{Field: path, In: "body", Message: msg}}
+	}
+
+	var errs []FieldError
+	for _, part := range schema.AllOf {
+		errs = append(errs, v.validateValue(path, part, value)...)
+	}
+
+	if value == nil {
+		if schema.Nullable || schema.Type == "" {
+			return errs
+		}
+		return append(errs, fail("must not be null")...)
+	}
+
+	switch schema.Type {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return fail("must be a string")
+		}
+		if msg := checkString(schema, s); msg != "" {
+			return fail(msg)
+		}
+	case "integer", "number":
+		typeMsg := "must be a number"
+		if schema.Type == "integer" {
+			typeMsg = "must be an integer"
+		}
+		n, ok := value.(json.Number)
+		if !ok {
+			return fail(typeMsg)
+		}
+		f, err := n.Float64()
+		if err != nil || (schema.Type == "integer" && f != math.Trunc(f)) {
+			return fail(typeMsg)
+		}
+		if msg := checkNumber(schema, f); msg != "" {
+			return fail(msg)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fail("must be a boolean")
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return fail("must be an array")
+		}
+		for i, item := range items {
+			errs = append(errs, v.validateValue(fmt.Sprintf("%s[%d]", path, i), schema.Items, item)...)
+		}
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			if path == "" {
+				return fail("request body must be a JSON object")
+			}
+			return fail("must be an object")
+		}
+		for _, name := range schema.Required {
+			if _, ok := object[name]; !ok {
+				errs = append(errs, FieldError{Field: join(path, name), In: "body", Message: "is required"})
+			}
+		}
+
+		names := make([]string, 0, len(schema.Properties))
+		for name := range schema.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if field, ok := object[name]; ok {
+				errs = append(errs, v.validateValue(join(path, name), schema.Properties[name], field)...)
+			}
+		}
+	}
+
+	return errs
+}
+
+func checkString(schema *Schema, s string) string {
+	length := utf8.RuneCountInString(s)
+	if schema.MinLength != nil && length < *schema.MinLength {
+		if *schema.MinLength == 1 {
+			return "must not be empty"
+		}
+		return fmt.Sprintf("must be at least %d characters", *schema.MinLength)
+	}
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		return fmt.Sprintf("must be at most %d characters", *schema.MaxLength)
+	}
+
+	if len(schema.Enum) > 0 {
+		allowed := make([]string, 0, len(schema.Enum))
+		for _, option := range schema.Enum {
+			if option == s {
+				return ""
+			}
+			allowed = append(allowed, fmt.Sprint(option))
+		}
+		return "must be one of: " + strings.Join(allowed, ", ")
+	}
+
+	switch schema.Format {
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			return "must be an RFC 3339 date-time"
+		}
+	case "date":
+		if _, err := time.Parse(time.DateOnly, s); err != nil {
+			return "must be a date in YYYY-MM-DD format"
+		}
+	case "uuid":
+		if !uuidRe.MatchString(s) {
+			return "must be a UUID"
+		}
+	}
+
+	return ""
+}
+
+func checkNumber(schema *Schema, n float64) string {
+	if schema.Minimum != nil && n < *schema.Minimum {
+		return fmt.Sprintf("must be at least %g", *schema.Minimum)
+	}
+	if schema.Maximum != nil && n > *schema.Maximum {
+		return fmt.Sprintf("must be at most %g", *schema.Maximum)
+	}
+	return ""
+}
+
+func isTemplate(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func writeErrors(w http.ResponseWriter, status int, code, message string, fields []FieldError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	errorResp := struct {
+		Error struct {
+			Code    string       `json:"code"`
+			Message string       `json:"message"`
+			Fields  []FieldError `json:"fields,omitempty"`
+		} `json:"error"`
+	}{}
+	errorResp.Error.Code = code
+	errorResp.Error.Message = message
+	errorResp.Error.Fields = fields
+
+	json.NewEncoder(w).Encode(errorResp)
+}
@@ -0,0 +1,287 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testSpec = `{
+  "paths": {
+    "/teams/list": {"get": {}},
+    "/teams/{name}": {"get": {}, "delete": {}},
+    "/teams/{name}/members": {"post": {}},
+    "/repositories/{name}/pulls/{number}": {"get": {}}
+  },
+  "components": {
+    "schemas": {
+      "Member": {
+        "type": "object",
+        "required": ["user_id"],
+        "properties": {
+          "user_id": {"type": "string", "minLength": 1, "maxLength": 50},
+          "is_active": {"type": "boolean"},
+          "max_open_reviews": {"type": "integer", "minimum": 0, "nullable": true},
+          "review_weight": {"type": "number", "minimum": 0}
+        }
+      },
+      "Team": {
+        "type": "object",
+        "required": ["team_name", "members"],
+        "properties": {
+          "team_name": {"type": "string"},
+          "members": {"type": "array", "items": {"$ref": "#/components/schemas/Member"}}
+        }
+      },
+      "ActiveMember": {
+        "allOf": [
+          {"$ref": "#/components/schemas/Member"},
+          {"type": "object", "required": ["is_active"]}
+        ]
+      }
+    }
+  }
+}`
+
+func testValidator(t *testing.T) *Validator {
+	t.Helper()
+
+	var doc Document
+	if err := json.Unmarshal([]byte(testSpec), &doc); err != nil {
+		t.Fatalf("parse spec: %v", err)
+	}
+	return NewValidator(&doc)
+}
+
+func TestMatch(t *testing.T) {
+	v := testValidator(t)
+	paths := v.doc.Paths
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		want       *Operation
+		wantParams map[string]string
+	}{
+		{"literal before template", "GET", "/teams/list", paths["/teams/list"]["get"], map[string]string{}},
+		{"template", "GET", "/teams/backend", paths["/teams/{name}"]["get"], map[string]string{"name": "backend"}},
+		{"method of template", "DELETE", "/teams/backend", paths["/teams/{name}"]["delete"], map[string]string{"name": "backend"}},
+		{"nested template", "POST", "/teams/backend/members", paths["/teams/{name}/members"]["post"], map[string]string{"name": "backend"}},
+		{"escaped slash in parameter", "GET", "/repositories/org%2Fapp/pulls/7", paths["/repositories/{name}/pulls/{number}"]["get"], map[string]string{"name": "org/app", "number": "7"}},
+		{"escaped space", "GET", "/teams/core%20team", paths["/teams/{name}"]["get"], map[string]string{"name": "core team"}},
+		{"unescaped slash splits", "GET", "/repositories/org/app/pulls/7", nil, nil},
+		{"unknown path", "GET", "/users/list", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, params := v.match(tt.method, tt.path)
+			if op != tt.want {
+				t.Errorf("operation = %p, want %p", op, tt.want)
+			}
+			if tt.want != nil && !reflect.DeepEqual(params, tt.wantParams) {
+				t.Errorf("params = %v, want %v", params, tt.wantParams)
+			}
+		})
+	}
+
+	// Метод, не описанный у буквального пути, не уходит в шаблонный
+	if op, _ := v.match("DELETE", "/teams/list"); op != nil {
+		t.Errorf("DELETE /teams/list matched %p, want nil", op)
+	}
+}
+
+func TestValidateValue(t *testing.T) {
+	v := testValidator(t)
+	ref := func(name string) *Schema {
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+
+	tests := []struct {
+		name   string
+		schema *Schema
+		body   string
+		want   []FieldError
+	}{
+		{
+			name:   "valid",
+			schema: ref("Member"),
+			body:   `{"user_id": "u1", "is_active": true, "max_open_reviews": 3, "review_weight": 0.5}`,
+		},
+		{
+			name:   "required",
+			schema: ref("Member"),
+			body:   `{"is_active": true}`,
+			want:   []FieldError{{Field: "user_id", In: "body", Message: "is required"}},
+		},
+		{
+			name:   "empty string",
+			schema: ref("Member"),
+			body:   `{"user_id": ""}`,
+			want:   []FieldError{{Field: "user_id", In: "body", Message: "must not be empty"}},
+		},
+		{
+			name:   "max length",
+			schema: ref("Member"),
+			body:   `{"user_id": "` + strings.Repeat("a", 51) + `"}`,
+			want:   []FieldError{{Field: "user_id", In: "body", Message: "must be at most 50 characters"}},
+		},
+		{
+			name:   "max length counts characters",
+			schema: ref("Member"),
+			body:   `{"user_id": "` + strings.Repeat("я", 50) + `"}`,
+		},
+		{
+			name:   "nullable",
+			schema: ref("Member"),
+			body:   `{"user_id": "u1", "max_open_reviews": null}`,
+		},
+		{
+			name:   "not nullable",
+			schema: ref("Member"),
+			body:   `{"user_id": null, "review_weight": null}`,
+			want: []FieldError{
+				{Field: "review_weight", In: "body", Message: "must not be null"},
+				{Field: "user_id", In: "body", Message: "must not be null"},
+			},
+		},
+		{
+			name:   "integer rejects fraction",
+			schema: ref("Member"),
+			body:   `{"user_id": "u1", "max_open_reviews": 1.5}`,
+			want:   []FieldError{{Field: "max_open_reviews", In: "body", Message: "must be an integer"}},
+		},
+		{
+			name:   "integer accepts whole float",
+			schema: ref("Member"),
+			body:   `{"user_id": "u1", "max_open_reviews": 2.0}`,
+		},
+		{
+			name:   "integer rejects string",
+			schema: ref("Member"),
+			body:   `{"user_id": "u1", "max_open_reviews": "2"}`,
+			want:   []FieldError{{Field: "max_open_reviews", In: "body", Message: "must be an integer"}},
+		},
+		{
+			name:   "number minimum",
+			schema: ref("Member"),
+			body:   `{"user_id": "u1", "review_weight": -0.5}`,
+			want:   []FieldError{{Field: "review_weight", In: "body", Message: "must be at least 0"}},
+		},
+		{
+			name:   "number rejects boolean",
+			schema: ref("Member"),
+			body:   `{"user_id": "u1", "review_weight": true}`,
+			want:   []FieldError{{Field: "review_weight", In: "body", Message: "must be a number"}},
+		},
+		{
+			name:   "nested array path",
+			schema: ref("Team"),
+			body:   `{"team_name": "backend", "members": [{"user_id": "u1"}, {"is_active": "yes"}]}`,
+			want: []FieldError{
+				{Field: "members[1].user_id", In: "body", Message: "is required"},
+				{Field: "members[1].is_active", In: "body", Message: "must be a boolean"},
+			},
+		},
+		{
+			name:   "allOf checks every part",
+			schema: ref("ActiveMember"),
+			body:   `{"max_open_reviews": -1}`,
+			want: []FieldError{
+				{Field: "user_id", In: "body", Message: "is required"},
+				{Field: "max_open_reviews", In: "body", Message: "must be at least 0"},
+				{Field: "is_active", In: "body", Message: "is required"},
+			},
+		},
+		{
+			name:   "body must be an object",
+			schema: ref("Team"),
+			body:   `[]`,
+			want:   []FieldError{{In: "body", Message: "request body must be a JSON object"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoder := json.NewDecoder(strings.NewReader(tt.body))
+			decoder.UseNumber()
+			var value any
+			if err := decoder.Decode(&value); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+
+			got := v.validateValue("", tt.schema, value)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("errors =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateParams(t *testing.T) {
+	v := testValidator(t)
+	minimum, maximum, maxLength := 1.0, 100.0, 50
+	op := &Operation{Parameters: []*Parameter{
+		{Name: "name", In: "path", Required: true, Schema: &Schema{Type: "string", MaxLength: &maxLength}},
+		{Name: "limit", In: "query", Schema: &Schema{Type: "integer", Minimum: &minimum, Maximum: &maximum}},
+		{Name: "status", In: "query", Schema: &Schema{Type: "string", Enum: []any{"OPEN", "MERGED"}}},
+		{Name: "include_merged", In: "query", Schema: &Schema{Type: "boolean"}},
+		{Name: "min_weight", In: "query", Schema: &Schema{Type: "number"}},
+		{Name: "q", In: "query", Required: true, Schema: &Schema{Type: "string"}},
+		{Name: "Idempotency-Key", In: "header", Required: true, Schema: &Schema{Type: "string"}},
+	}}
+
+	tests := []struct {
+		name  string
+		query string
+		path  map[string]string
+		want  []FieldError
+	}{
+		{
+			name:  "valid",
+			query: "q=fix&limit=100&status=OPEN&include_merged=true&min_weight=0.5",
+			path:  map[string]string{"name": "backend"},
+		},
+		{
+			name:  "required",
+			query: "q=",
+			path:  map[string]string{},
+			want: []FieldError{
+				{Field: "name", In: "path", Message: "is required"},
+				{Field: "q", In: "query", Message: "is required"},
+			},
+		},
+		{
+			name:  "typed values",
+			query: "q=fix&limit=0&status=closed&include_merged=maybe&min_weight=heavy",
+			path:  map[string]string{"name": strings.Repeat("n", 51)},
+			want: []FieldError{
+				{Field: "name", In: "path", Message: "must be at most 50 characters"},
+				{Field: "limit", In: "query", Message: "must be at least 1"},
+				{Field: "status", In: "query", Message: "must be one of: OPEN, MERGED"},
+				{Field: "include_merged", In: "query", Message: "must be true or false"},
+				{Field: "min_weight", In: "query", Message: "must be a number"},
+			},
+		},
+		{
+			name:  "integer",
+			query: "q=fix&limit=2.5",
+			path:  map[string]string{"name": "backend"},
+			want:  []FieldError{{Field: "limit", In: "query", Message: "must be an integer"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/teams/backend?"+tt.query, nil)
+
+			got := v.validateParams(op, r, tt.path)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("errors =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Package pathmatch сопоставляет пути файлов с шаблонами в стиле .gitignore/CODEOWNERS.
+package pathmatch
+
+import (
+	"path"
+	"strings"
+)
+
+// Match сообщает, подходит ли путь name под шаблон pattern.
+//
+// Правила:
+//   - ведущий "/" или "/" внутри шаблона привязывают его к корню репозитория,
+//     иначе шаблон ищется на любой глубине;
+//   - "*" и "?" не пересекают границу каталога, "**" - пересекает;
+//   - завершающий "/" означает каталог: совпадают только файлы внутри него;
+//   - шаблон, последний сегмент которого без метасимволов, совпадает и со всем
+//     содержимым одноименного каталога ("docs" покрывает "docs/a/b.md"),
+//     а "docs/*" - только с файлами непосредственно в docs.
+func Match(pattern, name string) bool {
+	pattern = strings.TrimSpace(pattern)
+	name = strings.Trim(name, "/")
+	if pattern == "" || name == "" {
+		return false
+	}
+
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	if pattern == "" {
+		return false
+	}
+	if !anchored && pattern != "**" {
+		pattern = "**/" + pattern
+	}
+
+	pat := strings.Split(pattern, "/")
+	segs := strings.Split(name, "/")
+	last := pat[len(pat)-1]
+
+	// Сам файл
+	if !dirOnly && matchSegments(pat, segs) {
+		return true
+	}
+
+	// Родительские каталоги файла
+	if !dirOnly && HasMeta(last) {
+		return false
+	}
+	for i := len(segs) - 1; i >= 1; i-- {
+		if matchSegments(pat, segs[:i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchSegments(pat, segs []string) bool {
+	for len(pat) > 0 {
+		if pat[0] == "**" {
+			rest := pat[1:]
+			if len(rest) == 0 {
+				return len(segs) > 0
+			}
+			for i := 0; i <= len(segs); i++ {
+				if matchSegments(rest, segs[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(segs) == 0 {
+			return false
+		}
+		ok, err := path.Match(pat[0], segs[0])
+		if err != nil || !ok {
+			return false
+		}
+		pat, segs = pat[1:], segs[1:]
+	}
+
+	return len(segs) == 0
+}
+
+// HasMeta сообщает, содержит ли строка метасимволы шаблона.
+func HasMeta(s string) bool {
+	return strings.ContainsAny(s, "*?[")
+}
@@ -0,0 +1,77 @@
+package pathmatch
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		// Непривязанные шаблоны ищутся на любой глубине
+		{"*.go", "main.go", true},
+		{"*.go", "internal/service/service.go", true},
+		{"*.go", "main.go.txt", false},
+		{"README.md", "docs/README.md", true},
+
+		// Привязанные к корню
+		{"/main.go", "main.go", true},
+		{"/main.go", "cmd/main.go", false},
+		{"cmd/*.go", "cmd/main.go", true},
+		{"cmd/*.go", "tools/cmd/main.go", false},
+
+		// "*" и "?" не пересекают каталог
+		{"/cmd/*", "cmd/main.go", true},
+		{"/cmd/*", "cmd/server/main.go", false},
+		{"/cmd/?.go", "cmd/a.go", true},
+		{"/cmd/?.go", "cmd/ab.go", false},
+
+		// Каталоги
+		{"docs/", "docs/a/b.md", true},
+		{"docs/", "docs", false},
+		{"docs/", "internal/docs/x.md", true},
+		{"/docs/", "internal/docs/x.md", false},
+		{"build/", "cmd/build/out", true},
+		{"docs", "docs/a/b.md", true},
+		{"docs", "docs", true},
+
+		// "**"
+		{"/services/**/migrations", "services/migrations/1.sql", true},
+		{"/services/**/migrations", "services/a/b/migrations/1.sql", true},
+		{"/services/**/migrations", "other/services/migrations/1.sql", false},
+		{"**/testdata", "a/b/testdata/x", true},
+		{"docs/**", "docs/a/b.md", true},
+		{"docs/**", "docs", false},
+		{"**", "anything/at/all", true},
+
+		// Пробелы в именах
+		{"/My Documents/", "My Documents/plan.txt", true},
+
+		// Пустые значения
+		{"", "main.go", false},
+		{"/", "main.go", false},
+		{"*.go", "", false},
+	}
+
+	for _, tt := range tests {
+		if got := Match(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHasMeta(t *testing.T) {
+	tests := map[string]bool{
+		"docs":   false,
+		"*.go":   true,
+		"a?b":    true,
+		"[ab]":   true,
+		"a/b/c/": false,
+	}
+
+	for s, want := range tests {
+		if got := HasMeta(s); got != want {
+			t.Errorf("HasMeta(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
@@ -0,0 +1,408 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"prmanager/internal/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ExportArchive выгружает все данные сервиса одним снимком (REPEATABLE READ),
+// чтобы архив был согласован даже при параллельной записи
+func (r *Repository) ExportArchive(ctx context.Context) (*models.Archive, error) {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	archive := &models.Archive{
+		Teams:           []models.ArchiveTeam{},
+		Users:           []models.User{},
+		Repositories:    []models.ArchiveRepository{},
+		PullRequests:    []models.ArchivePullRequest{},
+		OutOfOffice:     []models.OutOfOffice{},
+		AssignmentRules: []models.AssignmentRule{},
+	}
+
+	err = eachRow(ctx, tx,
+		`SELECT t.name, t.assignment_strategy, COALESCE(c.last_user_id, ''), t.created_at
+		 FROM teams t
+		 LEFT JOIN team_assignment_cursors c ON c.team_id = t.id
+		 ORDER BY t.name`,
+		func(rows pgx.Rows) error {
+			var team models.ArchiveTeam
+			if err := rows.Scan(&team.TeamName, &team.AssignmentStrategy, &team.RoundRobinLastUserID, &team.CreatedAt); err != nil {
+				return err
+			}
+			archive.Teams = append(archive.Teams, team)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("export teams: %w", err)
+	}
+
+	expertise := make(map[string][]string)
+	err = eachRow(ctx, tx,
+		"SELECT user_id, pattern FROM user_expertise ORDER BY user_id, pattern",
+		func(rows pgx.Rows) error {
+			var userID, pattern string
+			if err := rows.Scan(&userID, &pattern); err != nil {
+				return err
+			}
+			expertise[userID] = append(expertise[userID], pattern)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("export expertise: %w", err)
+	}
+
+	err = eachRow(ctx, tx,
+		`SELECT `+userColumns+`, t.name
+		 FROM users u
+		 JOIN teams t ON t.id = u.team_id
+		 ORDER BY u.id`,
+		func(rows pgx.Rows) error {
+			var user models.User
+			if err := rows.Scan(append(userFields(&user), &user.TeamName)...); err != nil {
+				return err
+			}
+			user.Expertise = expertise[user.UserID]
+			archive.Users = append(archive.Users, user)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("export users: %w", err)
+	}
+
+	err = eachRow(ctx, tx,
+		`SELECT r.name, r.default_reviewer_count, COALESCE(t.name, ''), r.created_at, COALESCE(c.content, '')
+		 FROM repositories r
+		 LEFT JOIN teams t ON t.id = r.team_id
+		 LEFT JOIN codeowners c ON c.repository = r.name
+		 ORDER BY r.name`,
+		func(rows pgx.Rows) error {
+			var repo models.ArchiveRepository
+			if err := rows.Scan(&repo.Name, &repo.DefaultReviewerCount, &repo.TeamName, &repo.CreatedAt, &repo.Codeowners); err != nil {
+				return err
+			}
+			archive.Repositories = append(archive.Repositories, repo)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("export repositories: %w", err)
+	}
+
+	reviewers := make(map[string][]models.ArchiveReviewer)
+	err = eachRow(ctx, tx,
+		"SELECT pr_id, user_id, assigned_at FROM pr_reviewers ORDER BY pr_id, assigned_at, user_id",
+		func(rows pgx.Rows) error {
+			var prID string
+			var reviewer models.ArchiveReviewer
+			if err := rows.Scan(&prID, &reviewer.UserID, &reviewer.AssignedAt); err != nil {
+				return err
+			}
+			reviewers[prID] = append(reviewers[prID], reviewer)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("export reviewers: %w", err)
+	}
+
+	shadows := make(map[string][]models.ArchiveShadowReviewer)
+	err = eachRow(ctx, tx,
+		"SELECT pr_id, user_id, COALESCE(mentor_id, ''), assigned_at FROM pr_shadow_reviewers ORDER BY pr_id, assigned_at, user_id",
+		func(rows pgx.Rows) error {
+			var prID string
+			var shadow models.ArchiveShadowReviewer
+			if err := rows.Scan(&prID, &shadow.UserID, &shadow.MentorID, &shadow.AssignedAt); err != nil {
+				return err
+			}
+			shadows[prID] = append(shadows[prID], shadow)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("export shadow reviewers: %w", err)
+	}
+
+	files := make(map[string][]string)
+	err = eachRow(ctx, tx,
+		"SELECT pr_id, path FROM pr_files ORDER BY pr_id, path",
+		func(rows pgx.Rows) error {
+			var prID, path string
+			if err := rows.Scan(&prID, &path); err != nil {
+				return err
+			}
+			files[prID] = append(files[prID], path)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("export files: %w", err)
+	}
+
+	err = eachRow(ctx, tx,
+		`SELECT pr.id, pr.title, pr.author_id, pr.status, COALESCE(pr.repository, ''), COALESCE(pr.number, 0),
+		        pr.created_at, pr.merged_at, pa.desired_count, pa.attempts, pa.queued_at
+		 FROM pull_requests pr
+		 LEFT JOIN pending_assignments pa ON pa.pr_id = pr.id
+		 ORDER BY pr.created_at, pr.id`,
+		func(rows pgx.Rows) error {
+			var pr models.ArchivePullRequest
+			var desiredCount, attempts *int
+			var queuedAt *time.Time
+			err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.Repository, &pr.Number,
+				&pr.CreatedAt, &pr.MergedAt, &desiredCount, &attempts, &queuedAt)
+			if err != nil {
+				return err
+			}
+
+			pr.Reviewers = reviewers[pr.PullRequestID]
+			if pr.Reviewers == nil {
+				pr.Reviewers = []models.ArchiveReviewer{}
+			}
+			pr.ShadowReviewers = shadows[pr.PullRequestID]
+			pr.Files = files[pr.PullRequestID]
+			if desiredCount != nil {
+				pr.PendingAssignment = &models.ArchivePendingAssignment{
+					DesiredCount: *desiredCount,
+					Attempts:     *attempts,
+					QueuedAt:     *queuedAt,
+				}
+			}
+
+			archive.PullRequests = append(archive.PullRequests, pr)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("export pull requests: %w", err)
+	}
+
+	err = eachRow(ctx, tx,
+		`SELECT id, user_id, starts_at, ends_at, COALESCE(reason, ''), COALESCE(external_uid, '')
+		 FROM user_out_of_office
+		 ORDER BY user_id, starts_at`,
+		func(rows pgx.Rows) error {
+			var ooo models.OutOfOffice
+			if err := rows.Scan(&ooo.ID, &ooo.UserID, &ooo.StartsAt, &ooo.EndsAt, &ooo.Reason, &ooo.ExternalUID); err != nil {
+				return err
+			}
+			archive.OutOfOffice = append(archive.OutOfOffice, ooo)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("export out of office: %w", err)
+	}
+
+	err = eachRow(ctx, tx,
+		`SELECT id, kind, author_id, reviewer_id, COALESCE(reason, ''), created_at
+		 FROM assignment_rules
+		 ORDER BY created_at, id`,
+		func(rows pgx.Rows) error {
+			var rule models.AssignmentRule
+			if err := rows.Scan(&rule.ID, &rule.Kind, &rule.AuthorID, &rule.ReviewerID, &rule.Reason, &rule.CreatedAt); err != nil {
+				return err
+			}
+			archive.AssignmentRules = append(archive.AssignmentRules, rule)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("export assignment rules: %w", err)
+	}
+
+	return archive, nil
+}
+
+// IsEmpty - в базе нет ни команд, ни пользователей, ни репозиториев, ни PR
+func (r *Repository) IsEmpty(ctx context.Context) (bool, error) {
+	var empty bool
+	err := r.db.QueryRow(ctx,
+		`SELECT NOT EXISTS(SELECT 1 FROM teams)
+		    AND NOT EXISTS(SELECT 1 FROM users)
+		    AND NOT EXISTS(SELECT 1 FROM repositories)
+		    AND NOT EXISTS(SELECT 1 FROM pull_requests)`,
+	).Scan(&empty)
+	if err != nil {
+		return false, fmt.Errorf("check database is empty: %w", err)
+	}
+	return empty, nil
+}
+
+// RestoreArchive записывает архив одной транзакцией. Id пользователей, PR,
+// периодов отсутствия и правил сохраняются (пустые id периодов и правил
+// генерируются), id команд и репозиториев создаются заново.
+func (r *Repository) RestoreArchive(ctx context.Context, archive *models.Archive) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	teamIDs := make(map[string]string, len(archive.Teams))
+	for _, team := range archive.Teams {
+		var teamID string
+		err := tx.QueryRow(ctx,
+			"INSERT INTO teams (name, assignment_strategy, created_at) VALUES ($1, COALESCE(NULLIF($2, ''), 'random'), $3) RETURNING id",
+			team.TeamName, team.AssignmentStrategy, team.CreatedAt,
+		).Scan(&teamID)
+		if err != nil {
+			return fmt.Errorf("insert team %s: %w", team.TeamName, err)
+		}
+		teamIDs[team.TeamName] = teamID
+	}
+
+	for _, user := range archive.Users {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO users (id, username, team_id, is_active, max_open_reviews, review_weight, is_trainee)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			user.UserID, user.Username, teamIDs[user.TeamName], user.IsActive, user.MaxOpenReviews, user.ReviewWeight, user.IsTrainee,
+		)
+		if err != nil {
+			return fmt.Errorf("insert user %s: %w", user.UserID, err)
+		}
+
+		if err := replaceExpertise(ctx, tx, user.UserID, user.Expertise); err != nil {
+			return err
+		}
+	}
+
+	// Позиция round_robin ссылается на пользователя, поэтому пишется после пользователей
+	for _, team := range archive.Teams {
+		if team.RoundRobinLastUserID == "" {
+			continue
+		}
+		_, err := tx.Exec(ctx,
+			"INSERT INTO team_assignment_cursors (team_id, last_user_id) VALUES ($1, $2)",
+			teamIDs[team.TeamName], team.RoundRobinLastUserID,
+		)
+		if err != nil {
+			return fmt.Errorf("insert assignment cursor %s: %w", team.TeamName, err)
+		}
+	}
+
+	for _, repo := range archive.Repositories {
+		var teamID *string
+		if id, ok := teamIDs[repo.TeamName]; ok {
+			teamID = &id
+		}
+
+		_, err := tx.Exec(ctx,
+			"INSERT INTO repositories (name, default_reviewer_count, team_id, created_at) VALUES ($1, $2, $3, $4)",
+			repo.Name, repo.DefaultReviewerCount, teamID, repo.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("insert repository %s: %w", repo.Name, err)
+		}
+
+		if repo.Codeowners != "" {
+			_, err = tx.Exec(ctx,
+				"INSERT INTO codeowners (repository, content) VALUES ($1, $2)",
+				repo.Name, repo.Codeowners,
+			)
+			if err != nil {
+				return fmt.Errorf("insert codeowners %s: %w", repo.Name, err)
+			}
+		}
+	}
+
+	for _, pr := range archive.PullRequests {
+		if err := restorePullRequest(ctx, tx, &pr); err != nil {
+			return fmt.Errorf("restore pull request %s: %w", pr.PullRequestID, err)
+		}
+	}
+
+	for _, ooo := range archive.OutOfOffice {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO user_out_of_office (id, user_id, starts_at, ends_at, reason, external_uid)
+			 VALUES (COALESCE(NULLIF($1, '')::uuid, gen_random_uuid()), $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''))`,
+			ooo.ID, ooo.UserID, ooo.StartsAt, ooo.EndsAt, ooo.Reason, ooo.ExternalUID,
+		)
+		if err != nil {
+			return fmt.Errorf("insert out of office %s: %w", ooo.ID, err)
+		}
+	}
+
+	for _, rule := range archive.AssignmentRules {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO assignment_rules (id, kind, author_id, reviewer_id, reason, created_at)
+			 VALUES (COALESCE(NULLIF($1, '')::uuid, gen_random_uuid()), $2, $3, $4, NULLIF($5, ''), $6)`,
+			rule.ID, rule.Kind, rule.AuthorID, rule.ReviewerID, rule.Reason, rule.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("insert assignment rule %s: %w", rule.ID, err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// restorePullRequest записывает PR из архива с исходным временем назначений
+func restorePullRequest(ctx context.Context, tx pgx.Tx, pr *models.ArchivePullRequest) error {
+	_, err := tx.Exec(ctx,
+		`INSERT INTO pull_requests (id, title, author_id, status, repository, number, created_at, merged_at)
+		 VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, 0), $7, $8)`,
+		pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, pr.Repository, pr.Number, pr.CreatedAt, pr.MergedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert pull request: %w", err)
+	}
+
+	for _, reviewer := range pr.Reviewers {
+		_, err = tx.Exec(ctx,
+			"INSERT INTO pr_reviewers (pr_id, user_id, assigned_at) VALUES ($1, $2, $3)",
+			pr.PullRequestID, reviewer.UserID, reviewer.AssignedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("insert reviewer %s: %w", reviewer.UserID, err)
+		}
+	}
+
+	for _, shadow := range pr.ShadowReviewers {
+		_, err = tx.Exec(ctx,
+			"INSERT INTO pr_shadow_reviewers (pr_id, user_id, mentor_id, assigned_at) VALUES ($1, $2, NULLIF($3, ''), $4)",
+			pr.PullRequestID, shadow.UserID, shadow.MentorID, shadow.AssignedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("insert shadow reviewer %s: %w", shadow.UserID, err)
+		}
+	}
+
+	for _, path := range pr.Files {
+		_, err = tx.Exec(ctx,
+			"INSERT INTO pr_files (pr_id, path) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+			pr.PullRequestID, path,
+		)
+		if err != nil {
+			return fmt.Errorf("insert file %s: %w", path, err)
+		}
+	}
+
+	if pending := pr.PendingAssignment; pending != nil {
+		_, err = tx.Exec(ctx,
+			"INSERT INTO pending_assignments (pr_id, desired_count, attempts, queued_at) VALUES ($1, $2, $3, $4)",
+			pr.PullRequestID, pending.DesiredCount, pending.Attempts, pending.QueuedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("insert pending assignment: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// eachRow выполняет запрос в транзакции и вызывает scan для каждой строки
+func eachRow(ctx context.Context, tx pgx.Tx, sql string, scan func(pgx.Rows) error) error {
+	rows, err := tx.Query(ctx, sql)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -2,15 +2,34 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"prmanager/internal/models"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// userColumns - колонки пользователя (алиас u), общие для всех выборок пользователей
+const userColumns = "u.id, u.username, u.is_active, u.max_open_reviews, u.review_weight, u.is_trainee"
+
+// userFields - приемники для userColumns
+func userFields(user *models.User) []any {
+	return []any{&user.UserID, &user.Username, &user.IsActive, &user.MaxOpenReviews, &user.ReviewWeight, &user.IsTrainee}
+}
+
+// ErrRoundRobinMoved - курсор round_robin сдвинулся между выбором ревьюеров и записью
+var ErrRoundRobinMoved = errors.New("round robin cursor moved")
+
+// ErrPendingAssignmentStale - PR из очереди обрабатывает другая реплика либо он
+// изменился (merge, переназначение) после подбора; добор повторится при следующем проходе
+var ErrPendingAssignmentStale = errors.New("pending assignment is stale")
+
 type Repository struct {
 	db     *pgxpool.Pool
 	logger *log.Logger
@@ -34,8 +53,8 @@ func (r *Repository) CreateTeam(ctx context.Context, team *models.Team) error {
 	// Создаем команду
 	var teamID string
 	err = tx.QueryRow(ctx,
-		"INSERT INTO teams (name) VALUES ($1) RETURNING id",
-		team.TeamName,
+		"INSERT INTO teams (name, assignment_strategy) VALUES ($1, COALESCE(NULLIF($2, ''), 'random')) RETURNING id",
+		team.TeamName, team.AssignmentStrategy,
 	).Scan(&teamID)
 	if err != nil {
 		return fmt.Errorf("insert team: %w", err)
@@ -64,6 +83,12 @@ func (r *Repository) CreateTeam(ctx context.Context, team *models.Team) error {
 		if err != nil {
 			return fmt.Errorf("upsert user %s: %w", member.UserID, err)
 		}
+
+		if member.Expertise != nil {
+			if err := replaceExpertise(ctx, tx, member.UserID, member.Expertise); err != nil {
+				return err
+			}
+		}
 	}
 
 	return tx.Commit(ctx)
@@ -74,7 +99,7 @@ func (r *Repository) GetTeam(ctx context.Context, teamName string) (*models.Team
 	team.TeamName = teamName
 
 	rows, err := r.db.Query(ctx,
-		`SELECT u.id, u.username, u.is_active 
+		`SELECT `+userColumns+`
 		 FROM users u 
 		 JOIN teams t ON u.team_id = t.id 
 		 WHERE t.name = $1`,
@@ -87,7 +112,7 @@ func (r *Repository) GetTeam(ctx context.Context, teamName string) (*models.Team
 
 	for rows.Next() {
 		var user models.User
-		err := rows.Scan(&user.UserID, &user.Username, &user.IsActive)
+		err := rows.Scan(userFields(&user)...)
 		if err != nil {
 			return nil, fmt.Errorf("scan user: %w", err)
 		}
@@ -99,6 +124,19 @@ func (r *Repository) GetTeam(ctx context.Context, teamName string) (*models.Team
 		return nil, fmt.Errorf("team not found")
 	}
 
+	team.AssignmentStrategy, err = r.GetTeamStrategy(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*models.User, len(team.Members))
+	for i := range team.Members {
+		members[i] = &team.Members[i]
+	}
+	if err := r.attachExpertise(ctx, members); err != nil {
+		return nil, err
+	}
+
 	return &team, nil
 }
 
@@ -111,6 +149,96 @@ func (r *Repository) TeamExists(ctx context.Context, teamName string) (bool, err
 	return exists, err
 }
 
+func (r *Repository) GetTeamStrategy(ctx context.Context, teamName string) (string, error) {
+	var strategy string
+	err := r.db.QueryRow(ctx,
+		"SELECT assignment_strategy FROM teams WHERE name = $1",
+		teamName,
+	).Scan(&strategy)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", fmt.Errorf("team not found")
+		}
+		return "", fmt.Errorf("query team strategy: %w", err)
+	}
+	return strategy, nil
+}
+
+func (r *Repository) SetTeamStrategy(ctx context.Context, teamName, strategy string) error {
+	_, err := r.db.Exec(ctx,
+		"UPDATE teams SET assignment_strategy = $1 WHERE name = $2",
+		strategy, teamName,
+	)
+	if err != nil {
+		return fmt.Errorf("update team strategy: %w", err)
+	}
+	return nil
+}
+
+// GetRoundRobinCursor возвращает последнего назначенного по round_robin пользователя
+// команды (nil - назначений еще не было). Курсор не блокируется: сдвиг записывается
+// вместе с назначением через advanceRoundRobin, который проверяет, что курсор не ушел.
+func (r *Repository) GetRoundRobinCursor(ctx context.Context, teamName string) (*string, error) {
+	var lastUserID *string
+	err := r.db.QueryRow(ctx,
+		`SELECT c.last_user_id
+		 FROM teams t
+		 LEFT JOIN team_assignment_cursors c ON c.team_id = t.id
+		 WHERE t.name = $1`,
+		teamName,
+	).Scan(&lastUserID)
+	if err != nil {
+		return nil, fmt.Errorf("team not found: %w", err)
+	}
+
+	return lastUserID, nil
+}
+
+// advanceRoundRobin сдвигает курсор команды в транзакции назначения. Если курсор
+// успел сдвинуться с момента выбора (конкурентное назначение), возвращает
+// ErrRoundRobinMoved: выбор нужно повторить, иначе два PR получат одного ревьюера.
+func advanceRoundRobin(ctx context.Context, tx pgx.Tx, advance *models.RoundRobinAdvance) error {
+	if advance == nil {
+		return nil
+	}
+
+	var teamID string
+	err := tx.QueryRow(ctx, "SELECT id FROM teams WHERE name = $1", advance.TeamName).Scan(&teamID)
+	if err != nil {
+		return fmt.Errorf("team not found: %w", err)
+	}
+
+	_, err = tx.Exec(ctx,
+		"INSERT INTO team_assignment_cursors (team_id) VALUES ($1) ON CONFLICT DO NOTHING",
+		teamID,
+	)
+	if err != nil {
+		return fmt.Errorf("init round robin cursor: %w", err)
+	}
+
+	var lastUserID *string
+	err = tx.QueryRow(ctx,
+		"SELECT last_user_id FROM team_assignment_cursors WHERE team_id = $1 FOR UPDATE",
+		teamID,
+	).Scan(&lastUserID)
+	if err != nil {
+		return fmt.Errorf("lock round robin cursor: %w", err)
+	}
+
+	if (lastUserID == nil) != (advance.From == nil) || (lastUserID != nil && *lastUserID != *advance.From) {
+		return ErrRoundRobinMoved
+	}
+
+	_, err = tx.Exec(ctx,
+		"UPDATE team_assignment_cursors SET last_user_id = $1, updated_at = NOW() WHERE team_id = $2",
+		advance.To, teamID,
+	)
+	if err != nil {
+		return fmt.Errorf("advance round robin cursor: %w", err)
+	}
+	return nil
+}
+
 func (r *Repository) GetTeamByName(ctx context.Context, teamName string) (*models.Team, error) {
 	return r.GetTeam(ctx, teamName)
 }
@@ -139,12 +267,12 @@ func (r *Repository) GetUser(ctx context.Context, userID string) (*models.User,
 	var teamName string
 
 	err := r.db.QueryRow(ctx,
-		`SELECT u.id, u.username, u.is_active, t.name 
+		`SELECT `+userColumns+`, t.name
 		 FROM users u 
 		 JOIN teams t ON u.team_id = t.id 
 		 WHERE u.id = $1`,
 		userID,
-	).Scan(&user.UserID, &user.Username, &user.IsActive, &teamName)
+	).Scan(append(userFields(&user), &teamName)...)
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -154,9 +282,41 @@ func (r *Repository) GetUser(ctx context.Context, userID string) (*models.User,
 	}
 
 	user.TeamName = teamName
+
+	if err := r.attachExpertise(ctx, []*models.User{&user}); err != nil {
+		return nil, err
+	}
+
 	return &user, nil
 }
 
+// FindUsersByIdentity ищет пользователей по id или username без учета регистра
+func (r *Repository) FindUsersByIdentity(ctx context.Context, identity string) ([]*models.User, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT `+userColumns+`, t.name
+		 FROM users u
+		 JOIN teams t ON u.team_id = t.id
+		 WHERE lower(u.id) = lower($1) OR lower(u.username) = lower($1)`,
+		identity,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query users by identity: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*models.User
+	for rows.Next() {
+		var user models.User
+		err := rows.Scan(append(userFields(&user), &user.TeamName)...)
+		if err != nil {
+			return nil, fmt.Errorf("scan user: %w", err)
+		}
+		users = append(users, &user)
+	}
+
+	return users, rows.Err()
+}
+
 func (r *Repository) UpdateUser(ctx context.Context, user *models.User) error {
 	// Находим team_id по team_name
 	var teamID string
@@ -187,12 +347,136 @@ func (r *Repository) UpdateUserActive(ctx context.Context, userID string, isActi
 	return r.GetUser(ctx, userID)
 }
 
+func (r *Repository) SetUserExpertise(ctx context.Context, userID string, expertise []string) (*models.User, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := replaceExpertise(ctx, tx, userID, expertise); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.GetUser(ctx, userID)
+}
+
+func (r *Repository) SetUserMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews *int) (*models.User, error) {
+	_, err := r.db.Exec(ctx,
+		"UPDATE users SET max_open_reviews = $1 WHERE id = $2",
+		maxOpenReviews, userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("update max open reviews: %w", err)
+	}
+
+	return r.GetUser(ctx, userID)
+}
+
+func (r *Repository) SetUserReviewWeight(ctx context.Context, userID string, weight float64) (*models.User, error) {
+	_, err := r.db.Exec(ctx,
+		"UPDATE users SET review_weight = $1 WHERE id = $2",
+		weight, userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("update review weight: %w", err)
+	}
+
+	return r.GetUser(ctx, userID)
+}
+
+func (r *Repository) SetUserTrainee(ctx context.Context, userID string, isTrainee bool) (*models.User, error) {
+	_, err := r.db.Exec(ctx,
+		"UPDATE users SET is_trainee = $1 WHERE id = $2",
+		isTrainee, userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("update trainee: %w", err)
+	}
+
+	return r.GetUser(ctx, userID)
+}
+
+// ApplyUserUpdate применяет все поля update одной транзакцией: либо все, либо ни одного
+func (r *Repository) ApplyUserUpdate(ctx context.Context, userID string, update models.UserUpdate) (*models.User, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx,
+		`UPDATE users SET
+		     is_active = COALESCE($2, is_active),
+		     max_open_reviews = CASE WHEN $3 THEN $4 ELSE max_open_reviews END,
+		     review_weight = COALESCE($5, review_weight),
+		     is_trainee = COALESCE($6, is_trainee)
+		 WHERE id = $1`,
+		userID, update.IsActive, update.SetMaxOpenReviews, update.MaxOpenReviews, update.ReviewWeight, update.IsTrainee,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("update user: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	if update.Expertise != nil {
+		if err := replaceExpertise(ctx, tx, userID, *update.Expertise); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.GetUser(ctx, userID)
+}
+
+// GetOpenReviewCounts - количество открытых PR на ревью у каждого из userIDs
+func (r *Repository) GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	counts := make(map[string]int)
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	rows, err := r.db.Query(ctx,
+		`SELECT prr.user_id, COUNT(*)
+		 FROM pr_reviewers prr
+		 JOIN pull_requests pr ON pr.id = prr.pr_id
+		 WHERE prr.user_id = ANY($1) AND pr.status = 'OPEN'
+		 GROUP BY prr.user_id`,
+		userIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query open review counts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID string
+		var count int
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, fmt.Errorf("scan open review count: %w", err)
+		}
+		counts[userID] = count
+	}
+
+	return counts, rows.Err()
+}
+
 func (r *Repository) GetActiveUsersByTeam(ctx context.Context, teamName string) ([]*models.User, error) {
 	rows, err := r.db.Query(ctx,
-		`SELECT u.id, u.username, u.is_active 
+		`SELECT `+userColumns+`
 		 FROM users u 
 		 JOIN teams t ON u.team_id = t.id 
-		 WHERE t.name = $1 AND u.is_active = true`,
+		 WHERE t.name = $1 AND u.is_active = true
+		 ORDER BY u.id`,
 		teamName,
 	)
 	if err != nil {
@@ -203,7 +487,7 @@ func (r *Repository) GetActiveUsersByTeam(ctx context.Context, teamName string)
 	var users []*models.User
 	for rows.Next() {
 		var user models.User
-		err := rows.Scan(&user.UserID, &user.Username, &user.IsActive)
+		err := rows.Scan(userFields(&user)...)
 		if err != nil {
 			return nil, fmt.Errorf("scan user: %w", err)
 		}
@@ -211,15 +495,20 @@ func (r *Repository) GetActiveUsersByTeam(ctx context.Context, teamName string)
 		users = append(users, &user)
 	}
 
+	if err := r.attachExpertise(ctx, users); err != nil {
+		return nil, err
+	}
+
 	return users, nil
 }
 
 func (r *Repository) GetUsersByTeamName(ctx context.Context, teamName string) ([]*models.User, error) {
 	rows, err := r.db.Query(ctx,
-		`SELECT u.id, u.username, u.is_active 
+		`SELECT `+userColumns+`
 		 FROM users u 
 		 JOIN teams t ON u.team_id = t.id 
-		 WHERE t.name = $1`,
+		 WHERE t.name = $1
+		 ORDER BY u.id`,
 		teamName,
 	)
 	if err != nil {
@@ -230,7 +519,7 @@ func (r *Repository) GetUsersByTeamName(ctx context.Context, teamName string) ([
 	var users []*models.User
 	for rows.Next() {
 		var user models.User
-		err := rows.Scan(&user.UserID, &user.Username, &user.IsActive)
+		err := rows.Scan(userFields(&user)...)
 		if err != nil {
 			return nil, fmt.Errorf("scan user: %w", err)
 		}
@@ -238,21 +527,102 @@ func (r *Repository) GetUsersByTeamName(ctx context.Context, teamName string) ([
 		users = append(users, &user)
 	}
 
+	if err := r.attachExpertise(ctx, users); err != nil {
+		return nil, err
+	}
+
 	return users, nil
 }
 
 // Pull Requests
-func (r *Repository) CreatePullRequest(ctx context.Context, pr *models.PullRequest) error {
+
+// CreatePullRequest создает PR одной транзакцией вместе со сдвигом курсора
+// round_robin (advance, может быть nil) и, если pendingCount > 0, с постановкой
+// в очередь на добор до pendingCount ревьюеров. event записывается в той же транзакции.
+func (r *Repository) CreatePullRequest(ctx context.Context, pr *models.PullRequest, pendingCount int, advance *models.RoundRobinAdvance, event *models.PullRequestEvent) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := advanceRoundRobin(ctx, tx, advance); err != nil {
+		return err
+	}
+
+	if err := insertPullRequest(ctx, tx, pr, nil); err != nil {
+		return err
+	}
+
+	if pendingCount > 0 {
+		_, err = tx.Exec(ctx,
+			"INSERT INTO pending_assignments (pr_id, desired_count) VALUES ($1, $2)",
+			pr.PullRequestID, pendingCount,
+		)
+		if err != nil {
+			return fmt.Errorf("insert pending assignment: %w", err)
+		}
+	}
+
+	if err := insertPullRequestEvent(ctx, tx, event); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ImportPullRequests создает PR одной транзакцией: либо все, либо ни одного.
+// Время создания и мержа берется из PR; pendingCounts - желаемое число ревьюеров
+// PR, которые ставятся в очередь на добор. advances - сдвиги курсоров round_robin
+// в порядке подбора: каждый следующий продолжает предыдущий, поэтому применяются
+// по очереди и откатываются вместе с PR. events - события создания PR, пишутся
+// в той же транзакции.
+func (r *Repository) ImportPullRequests(ctx context.Context, prs []*models.PullRequest, pendingCounts map[string]int, advances []*models.RoundRobinAdvance, events []*models.PullRequestEvent) error {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("begin transaction: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
+	for _, advance := range advances {
+		if err := advanceRoundRobin(ctx, tx, advance); err != nil {
+			return err
+		}
+	}
+
+	for _, pr := range prs {
+		if err := insertPullRequest(ctx, tx, pr, &pr.CreatedAt); err != nil {
+			return fmt.Errorf("import %s: %w", pr.PullRequestID, err)
+		}
+
+		if desiredCount, ok := pendingCounts[pr.PullRequestID]; ok {
+			_, err = tx.Exec(ctx,
+				"INSERT INTO pending_assignments (pr_id, desired_count) VALUES ($1, $2)",
+				pr.PullRequestID, desiredCount,
+			)
+			if err != nil {
+				return fmt.Errorf("insert pending assignment for %s: %w", pr.PullRequestID, err)
+			}
+		}
+	}
+
+	for _, event := range events {
+		if err := insertPullRequestEvent(ctx, tx, event); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// insertPullRequest записывает PR с ревьюерами, стажерами и файлами.
+// createdAt задает время создания PR и назначения ревьюеров; nil - текущее время.
+func insertPullRequest(ctx context.Context, tx pgx.Tx, pr *models.PullRequest, createdAt *time.Time) error {
 	// Создаем PR
-	_, err = tx.Exec(ctx,
-		"INSERT INTO pull_requests (id, title, author_id, status) VALUES ($1, $2, $3, $4)",
-		pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status,
+	_, err := tx.Exec(ctx,
+		`INSERT INTO pull_requests (id, title, author_id, status, repository, number, created_at, merged_at)
+		 VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, 0), COALESCE($7, NOW()), $8)`,
+		pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, pr.Repository, pr.Number, createdAt, pr.MergedAt,
 	)
 	if err != nil {
 		return fmt.Errorf("insert pull request: %w", err)
@@ -261,15 +631,30 @@ func (r *Repository) CreatePullRequest(ctx context.Context, pr *models.PullReque
 	// Назначаем ревьюеров
 	for _, reviewerID := range pr.AssignedReviewers {
 		_, err = tx.Exec(ctx,
-			"INSERT INTO pr_reviewers (pr_id, user_id) VALUES ($1, $2)",
-			pr.PullRequestID, reviewerID,
+			"INSERT INTO pr_reviewers (pr_id, user_id, assigned_at) VALUES ($1, $2, COALESCE($3, NOW()))",
+			pr.PullRequestID, reviewerID, createdAt,
 		)
 		if err != nil {
 			return fmt.Errorf("assign reviewer %s: %w", reviewerID, err)
 		}
 	}
 
-	return tx.Commit(ctx)
+	if err := insertShadowReviewers(ctx, tx, pr.PullRequestID, pr.ShadowReviewers); err != nil {
+		return err
+	}
+
+	// Сохраняем измененные файлы
+	for _, path := range pr.Files {
+		_, err = tx.Exec(ctx,
+			"INSERT INTO pr_files (pr_id, path) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+			pr.PullRequestID, path,
+		)
+		if err != nil {
+			return fmt.Errorf("insert file %s: %w", path, err)
+		}
+	}
+
+	return nil
 }
 
 func (r *Repository) GetPullRequest(ctx context.Context, prID string) (*models.PullRequest, error) {
@@ -279,11 +664,13 @@ func (r *Repository) GetPullRequest(ctx context.Context, prID string) (*models.P
 
 	// Получаем основную информацию о PR
 	err := r.db.QueryRow(ctx,
-		`SELECT id, title, author_id, status, created_at, merged_at 
+		`SELECT id, title, author_id, status, COALESCE(repository, ''), COALESCE(number, 0), created_at, merged_at,
+		        EXISTS(SELECT 1 FROM pending_assignments WHERE pr_id = pull_requests.id)
 		 FROM pull_requests 
 		 WHERE id = $1`,
 		prID,
-	).Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &createdAt, &mergedAt)
+	).Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.Repository, &pr.Number, &createdAt, &mergedAt,
+		&pr.PendingAssignment)
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -314,99 +701,414 @@ func (r *Repository) GetPullRequest(ctx context.Context, prID string) (*models.P
 		pr.AssignedReviewers = append(pr.AssignedReviewers, reviewerID)
 	}
 
-	return &pr, nil
-}
-
-func (r *Repository) UpdatePullRequestStatus(ctx context.Context, prID, status string, mergedAt *time.Time) (*models.PullRequest, error) {
-	tx, err := r.db.Begin(ctx)
+	pr.Files, err = r.getPullRequestFiles(ctx, prID)
 	if err != nil {
-		return nil, fmt.Errorf("begin transaction: %w", err)
+		return nil, err
 	}
-	defer tx.Rollback(ctx)
 
-	if mergedAt != nil {
-		_, err = tx.Exec(ctx,
-			"UPDATE pull_requests SET status = $1, merged_at = $2 WHERE id = $3",
-			status, mergedAt, prID,
-		)
-	} else {
-		_, err = tx.Exec(ctx,
-			"UPDATE pull_requests SET status = $1 WHERE id = $2",
-			status, prID,
-		)
-	}
+	pr.ShadowReviewers, err = r.getShadowReviewers(ctx, prID)
 	if err != nil {
-		return nil, fmt.Errorf("update pull request status: %w", err)
-	}
-
-	if err := tx.Commit(ctx); err != nil {
 		return nil, err
 	}
 
-	return r.GetPullRequest(ctx, prID)
+	return &pr, nil
 }
 
-func (r *Repository) GetPullRequestsByReviewer(ctx context.Context, userID string) ([]*models.PullRequestShort, error) {
-	rows, err := r.db.Query(ctx,
-		`SELECT pr.id, pr.title, pr.author_id, pr.status
-		 FROM pull_requests pr
-		 JOIN pr_reviewers prr ON pr.id = prr.pr_id
-		 WHERE prr.user_id = $1`,
-		userID,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("query pull requests by reviewer: %w", err)
+// ListPullRequests возвращает страницу PR по фильтру. Пагинация по ключу
+// (значение сортировки, id PR): страница начинается строго после filter.After.
+func (r *Repository) ListPullRequests(ctx context.Context, filter models.PullRequestFilter) ([]*models.PullRequestListItem, error) {
+	var conditions []string
+	var args []any
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
 	}
-	defer rows.Close()
 
-	var prs []*models.PullRequestShort
-	for rows.Next() {
-		var pr models.PullRequestShort
-		err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status)
-		if err != nil {
-			return nil, fmt.Errorf("scan pull request: %w", err)
-		}
-		prs = append(prs, &pr)
+	rankColumn := "NULL::float8"
+	if filter.Query != "" {
+		query := "websearch_to_tsquery('simple', " + arg(filter.Query) + ")"
+		conditions = append(conditions, "pr.title_tsv @@ "+query)
+		rankColumn = "ts_rank(pr.title_tsv, " + query + ")::float8"
 	}
 
-	return prs, nil
-}
-
-func (r *Repository) AssignReviewers(ctx context.Context, prID string, reviewerIDs []string) error {
-	tx, err := r.db.Begin(ctx)
-	if err != nil {
-		return fmt.Errorf("begin transaction: %w", err)
+	if filter.Status != "" {
+		conditions = append(conditions, "pr.status = "+arg(filter.Status))
+	}
+	if filter.AuthorID != "" {
+		conditions = append(conditions, "pr.author_id = "+arg(filter.AuthorID))
+	}
+	if filter.TeamName != "" {
+		conditions = append(conditions,
+			"pr.author_id IN (SELECT u.id FROM users u JOIN teams t ON t.id = u.team_id WHERE t.name = "+arg(filter.TeamName)+")")
+	}
+	if filter.ReviewerID != "" {
+		conditions = append(conditions,
+			"EXISTS(SELECT 1 FROM pr_reviewers prr WHERE prr.pr_id = pr.id AND prr.user_id = "+arg(filter.ReviewerID)+")")
+	}
+	if filter.Repository != "" {
+		conditions = append(conditions, "pr.repository = "+arg(filter.Repository))
+	}
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, "pr.created_at >= "+arg(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, "pr.created_at < "+arg(*filter.CreatedTo))
+	}
+	if filter.MergedFrom != nil {
+		conditions = append(conditions, "pr.merged_at >= "+arg(*filter.MergedFrom))
+	}
+	if filter.MergedTo != nil {
+		conditions = append(conditions, "pr.merged_at < "+arg(*filter.MergedTo))
+	}
+
+	sortColumn := "pr.created_at"
+	switch filter.SortBy {
+	case models.PullRequestSortMerged:
+		sortColumn = "pr.merged_at"
+		conditions = append(conditions, "pr.merged_at IS NOT NULL")
+	case models.PullRequestSortRelevance:
+		sortColumn = rankColumn
+	}
+
+	direction, cmp := "DESC", "<"
+	if filter.Ascending {
+		direction, cmp = "ASC", ">"
+	}
+
+	if after := filter.After; after != nil {
+		var value any
+		if after.Rank != nil {
+			value = *after.Rank
+		} else {
+			value = *after.Time
+		}
+		conditions = append(conditions, "("+sortColumn+", pr.id) "+cmp+" ("+arg(value)+", "+arg(after.ID)+")")
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query := fmt.Sprintf(
+		`SELECT pr.id, pr.title, pr.author_id, pr.status, COALESCE(pr.repository, ''), COALESCE(pr.number, 0),
+		        pr.created_at, pr.merged_at,
+		        ARRAY(SELECT prr.user_id FROM pr_reviewers prr WHERE prr.pr_id = pr.id ORDER BY prr.assigned_at, prr.user_id),
+		        EXISTS(SELECT 1 FROM pending_assignments pa WHERE pa.pr_id = pr.id),
+		        %s
+		 FROM pull_requests pr
+		 %s
+		 ORDER BY %s %s, pr.id %s
+		 LIMIT %s`,
+		rankColumn, where, sortColumn, direction, direction, arg(filter.Limit),
+	)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query pull requests: %w", err)
+	}
+	defer rows.Close()
+
+	var prs []*models.PullRequestListItem
+	for rows.Next() {
+		var pr models.PullRequestListItem
+		err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.Repository, &pr.Number,
+			&pr.CreatedAt, &pr.MergedAt, &pr.AssignedReviewers, &pr.PendingAssignment, &pr.Rank)
+		if err != nil {
+			return nil, fmt.Errorf("scan pull request: %w", err)
+		}
+		prs = append(prs, &pr)
+	}
+
+	return prs, rows.Err()
+}
+
+func insertShadowReviewers(ctx context.Context, tx pgx.Tx, prID string, shadows []models.ShadowReviewer) error {
+	for _, shadow := range shadows {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO pr_shadow_reviewers (pr_id, user_id, mentor_id) VALUES ($1, $2, NULLIF($3, ''))
+			 ON CONFLICT DO NOTHING`,
+			prID, shadow.UserID, shadow.MentorID,
+		)
+		if err != nil {
+			return fmt.Errorf("assign shadow reviewer %s: %w", shadow.UserID, err)
+		}
+	}
+	return nil
+}
+
+func (r *Repository) getShadowReviewers(ctx context.Context, prID string) ([]models.ShadowReviewer, error) {
+	rows, err := r.db.Query(ctx,
+		"SELECT user_id, COALESCE(mentor_id, '') FROM pr_shadow_reviewers WHERE pr_id = $1 ORDER BY assigned_at, user_id",
+		prID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query shadow reviewers: %w", err)
+	}
+	defer rows.Close()
+
+	var shadows []models.ShadowReviewer
+	for rows.Next() {
+		var shadow models.ShadowReviewer
+		if err := rows.Scan(&shadow.UserID, &shadow.MentorID); err != nil {
+			return nil, fmt.Errorf("scan shadow reviewer: %w", err)
+		}
+		shadows = append(shadows, shadow)
+	}
+
+	return shadows, rows.Err()
+}
+
+func (r *Repository) getPullRequestFiles(ctx context.Context, prID string) ([]string, error) {
+	rows, err := r.db.Query(ctx,
+		"SELECT path FROM pr_files WHERE pr_id = $1 ORDER BY path",
+		prID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query files: %w", err)
+	}
+	defer rows.Close()
+
+	var files []string
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, fmt.Errorf("scan file: %w", err)
+		}
+		files = append(files, path)
+	}
+
+	return files, rows.Err()
+}
+
+// UpdatePullRequestStatus меняет статус PR и записывает event в той же транзакции
+func (r *Repository) UpdatePullRequestStatus(ctx context.Context, prID, status string, mergedAt *time.Time, event *models.PullRequestEvent) (*models.PullRequest, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if mergedAt != nil {
+		_, err = tx.Exec(ctx,
+			"UPDATE pull_requests SET status = $1, merged_at = $2 WHERE id = $3",
+			status, mergedAt, prID,
+		)
+	} else {
+		_, err = tx.Exec(ctx,
+			"UPDATE pull_requests SET status = $1 WHERE id = $2",
+			status, prID,
+		)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("update pull request status: %w", err)
+	}
+
+	if err := insertPullRequestEvent(ctx, tx, event); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.GetPullRequest(ctx, prID)
+}
+
+// GetPullRequestsByReviewer возвращает страницу PR, назначенных filter.ReviewerID.
+// Пагинация по ключу (время сортировки, id PR): страница начинается строго после filter.After.
+func (r *Repository) GetPullRequestsByReviewer(ctx context.Context, filter models.ReviewFilter) ([]*models.UserReview, error) {
+	sortColumn := "pr.created_at"
+	if filter.SortBy == models.ReviewSortAssigned {
+		sortColumn = "prr.assigned_at"
+	}
+	direction, cmp := "DESC", "<"
+	if filter.Ascending {
+		direction, cmp = "ASC", ">"
+	}
+
+	conditions := []string{"prr.user_id = $1"}
+	args := []any{filter.ReviewerID}
+	where := func(condition string, values ...any) {
+		placeholders := make([]any, len(values))
+		for i, value := range values {
+			args = append(args, value)
+			placeholders[i] = len(args)
+		}
+		conditions = append(conditions, fmt.Sprintf(condition, placeholders...))
+	}
+
+	if filter.Status != "" {
+		where("pr.status = $%d", filter.Status)
+	}
+	if filter.AuthorID != "" {
+		where("pr.author_id = $%d", filter.AuthorID)
+	}
+	if filter.Repository != "" {
+		where("pr.repository = $%d", filter.Repository)
+	}
+	if filter.From != nil {
+		where(sortColumn+" >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		where(sortColumn+" < $%d", *filter.To)
+	}
+	if filter.After != nil {
+		where("("+sortColumn+", pr.id) "+cmp+" ($%d, $%d)", filter.After.Time, filter.After.ID)
+	}
+	args = append(args, filter.Limit)
+
+	query := fmt.Sprintf(
+		`SELECT pr.id, pr.title, pr.author_id, pr.status, COALESCE(pr.repository, ''),
+		        pr.created_at, prr.assigned_at, pr.merged_at
+		 FROM pull_requests pr
+		 JOIN pr_reviewers prr ON pr.id = prr.pr_id
+		 WHERE %s
+		 ORDER BY %s %s, pr.id %s
+		 LIMIT $%d`,
+		strings.Join(conditions, " AND "), sortColumn, direction, direction, len(args),
+	)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query pull requests by reviewer: %w", err)
+	}
+	defer rows.Close()
+
+	var prs []*models.UserReview
+	for rows.Next() {
+		var pr models.UserReview
+		err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.Repository,
+			&pr.CreatedAt, &pr.AssignedAt, &pr.MergedAt)
+		if err != nil {
+			return nil, fmt.Errorf("scan pull request: %w", err)
+		}
+		prs = append(prs, &pr)
+	}
+
+	return prs, rows.Err()
+}
+
+// FillPendingAssignment записывает добор ревьюеров PR из очереди одной транзакцией.
+// Строка очереди захватывается FOR UPDATE SKIP LOCKED, поэтому реплики не добирают
+// один PR одновременно; PR блокируется и перепроверяется: если он смержен или его
+// ревьюеры изменились после подбора, возвращается ErrPendingAssignmentStale.
+func (r *Repository) FillPendingAssignment(ctx context.Context, fill *models.PendingAssignmentFill) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
-	for _, reviewerID := range reviewerIDs {
+	var claimed string
+	err = tx.QueryRow(ctx,
+		"SELECT pr_id FROM pending_assignments WHERE pr_id = $1 FOR UPDATE SKIP LOCKED",
+		fill.PullRequestID,
+	).Scan(&claimed)
+	if err == pgx.ErrNoRows {
+		return ErrPendingAssignmentStale
+	}
+	if err != nil {
+		return fmt.Errorf("claim pending assignment: %w", err)
+	}
+
+	var status string
+	err = tx.QueryRow(ctx,
+		"SELECT status FROM pull_requests WHERE id = $1 FOR UPDATE",
+		fill.PullRequestID,
+	).Scan(&status)
+	if err != nil {
+		return fmt.Errorf("lock pull request: %w", err)
+	}
+	if status != "OPEN" {
+		return ErrPendingAssignmentStale
+	}
+
+	var reviewers []string
+	err = tx.QueryRow(ctx,
+		"SELECT COALESCE(array_agg(user_id), '{}') FROM pr_reviewers WHERE pr_id = $1",
+		fill.PullRequestID,
+	).Scan(&reviewers)
+	if err != nil {
+		return fmt.Errorf("count reviewers: %w", err)
+	}
+	expected := slices.Clone(fill.Reviewers)
+	slices.Sort(expected)
+	slices.Sort(reviewers)
+	if !slices.Equal(reviewers, expected) {
+		return ErrPendingAssignmentStale
+	}
+
+	if err := advanceRoundRobin(ctx, tx, fill.Advance); err != nil {
+		return err
+	}
+
+	for _, reviewerID := range fill.Added {
 		_, err = tx.Exec(ctx,
 			"INSERT INTO pr_reviewers (pr_id, user_id) VALUES ($1, $2)",
-			prID, reviewerID,
+			fill.PullRequestID, reviewerID,
 		)
 		if err != nil {
 			return fmt.Errorf("assign reviewer %s: %w", reviewerID, err)
 		}
 	}
 
+	// Стажер добавляется, только если у PR его еще нет
+	if fill.Shadow != nil {
+		_, err = tx.Exec(ctx,
+			`INSERT INTO pr_shadow_reviewers (pr_id, user_id, mentor_id)
+			 SELECT $1, $2, NULLIF($3, '')
+			 WHERE NOT EXISTS (SELECT 1 FROM pr_shadow_reviewers WHERE pr_id = $1)`,
+			fill.PullRequestID, fill.Shadow.UserID, fill.Shadow.MentorID,
+		)
+		if err != nil {
+			return fmt.Errorf("assign shadow reviewer %s: %w", fill.Shadow.UserID, err)
+		}
+	}
+
+	if fill.Done {
+		_, err = tx.Exec(ctx, "DELETE FROM pending_assignments WHERE pr_id = $1", fill.PullRequestID)
+		if err != nil {
+			return fmt.Errorf("delete pending assignment: %w", err)
+		}
+	}
+
+	if err := insertPullRequestEvent(ctx, tx, fill.Event); err != nil {
+		return err
+	}
+
 	return tx.Commit(ctx)
 }
 
-func (r *Repository) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) error {
+// ReassignReviewer заменяет ревьюера PR и записывает event в той же транзакции
+func (r *Repository) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string, event *models.PullRequestEvent) error {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("begin transaction: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
+	// PR блокируется, чтобы переназначение не пересеклось с добором из очереди
+	var status string
+	err = tx.QueryRow(ctx, "SELECT status FROM pull_requests WHERE id = $1 FOR UPDATE", prID).Scan(&status)
+	if err != nil {
+		return fmt.Errorf("lock pull request: %w", err)
+	}
+	if status != "OPEN" {
+		return fmt.Errorf("pull request %s is %s", prID, status)
+	}
+
 	// Удаляем старого ревьюера
-	_, err = tx.Exec(ctx,
+	tag, err := tx.Exec(ctx,
 		"DELETE FROM pr_reviewers WHERE pr_id = $1 AND user_id = $2",
 		prID, oldReviewerID,
 	)
 	if err != nil {
 		return fmt.Errorf("remove old reviewer: %w", err)
 	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("reviewer %s is no longer assigned", oldReviewerID)
+	}
 
 	// Добавляем нового ревьюера
 	_, err = tx.Exec(ctx,
@@ -417,6 +1119,19 @@ func (r *Repository) ReassignReviewer(ctx context.Context, prID, oldReviewerID,
 		return fmt.Errorf("add new reviewer: %w", err)
 	}
 
+	// Стажер переходит к новому наставнику
+	_, err = tx.Exec(ctx,
+		"UPDATE pr_shadow_reviewers SET mentor_id = $1 WHERE pr_id = $2 AND mentor_id = $3",
+		newReviewerID, prID, oldReviewerID,
+	)
+	if err != nil {
+		return fmt.Errorf("move shadow reviewer: %w", err)
+	}
+
+	if err := insertPullRequestEvent(ctx, tx, event); err != nil {
+		return err
+	}
+
 	return tx.Commit(ctx)
 }
 
@@ -428,3 +1143,735 @@ func (r *Repository) PRExists(ctx context.Context, prID string) (bool, error) {
 	).Scan(&exists)
 	return exists, err
 }
+
+func (r *Repository) PRNumberExists(ctx context.Context, repository string, number int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx,
+		"SELECT EXISTS(SELECT 1 FROM pull_requests WHERE repository = $1 AND number = $2)",
+		repository, number,
+	).Scan(&exists)
+	return exists, err
+}
+
+// Assignment explanations
+func (r *Repository) SaveAssignmentExplanation(ctx context.Context, explanation *models.AssignmentExplanation) error {
+	data, err := json.Marshal(explanation)
+	if err != nil {
+		return fmt.Errorf("encode assignment explanation: %w", err)
+	}
+
+	_, err = r.db.Exec(ctx,
+		"INSERT INTO pr_assignment_explanations (pr_id, event, explanation) VALUES ($1, $2, $3)",
+		explanation.PullRequestID, explanation.Event, data,
+	)
+	if err != nil {
+		return fmt.Errorf("insert assignment explanation: %w", err)
+	}
+	return nil
+}
+
+// GetAssignmentExplanations возвращает объяснения назначений PR в порядке записи
+func (r *Repository) GetAssignmentExplanations(ctx context.Context, prID string) ([]*models.AssignmentExplanation, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT explanation, created_at
+		 FROM pr_assignment_explanations
+		 WHERE pr_id = $1
+		 ORDER BY id`,
+		prID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query assignment explanations: %w", err)
+	}
+	defer rows.Close()
+
+	var explanations []*models.AssignmentExplanation
+	for rows.Next() {
+		var (
+			data      []byte
+			createdAt time.Time
+		)
+		if err := rows.Scan(&data, &createdAt); err != nil {
+			return nil, fmt.Errorf("scan assignment explanation: %w", err)
+		}
+
+		var explanation models.AssignmentExplanation
+		if err := json.Unmarshal(data, &explanation); err != nil {
+			return nil, fmt.Errorf("decode assignment explanation: %w", err)
+		}
+		explanation.CreatedAt = &createdAt
+		explanations = append(explanations, &explanation)
+	}
+
+	return explanations, rows.Err()
+}
+
+// Events
+
+// insertPullRequestEvent записывает событие в транзакции изменения PR: событие
+// фиксируется вместе с изменением или не фиксируется вовсе. Заполняет ID, Teams
+// и CreatedAt; команды участников определяются по пользователям в момент записи.
+func insertPullRequestEvent(ctx context.Context, tx pgx.Tx, event *models.PullRequestEvent) error {
+	if event == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encode pull request event: %w", err)
+	}
+
+	// Одна запись за раз: id выдаются в порядке фиксации транзакций. Блокировка
+	// держится до конца транзакции, поэтому событие пишется последним шагом.
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext('pr_events'))"); err != nil {
+		return fmt.Errorf("lock pull request events: %w", err)
+	}
+
+	err = tx.QueryRow(ctx,
+		`INSERT INTO pr_events (type, pr_id, user_ids, team_names, data)
+		 VALUES ($1, $2, $3::varchar[], ARRAY(
+		     SELECT DISTINCT t.name FROM users u JOIN teams t ON t.id = u.team_id WHERE u.id = ANY($3)
+		 ), $4)
+		 RETURNING id, team_names, created_at`,
+		event.Type, event.PullRequestID, event.UserIDs, data,
+	).Scan(&event.ID, &event.Teams, &event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert pull request event: %w", err)
+	}
+	return nil
+}
+
+// GetPullRequestEvents возвращает события после filter.AfterID по возрастанию id
+func (r *Repository) GetPullRequestEvents(ctx context.Context, filter models.EventFilter) ([]*models.PullRequestEvent, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT id, team_names, data, created_at
+		 FROM pr_events
+		 WHERE id > $1
+		   AND ($2 = '' OR team_names @> ARRAY[$2::varchar])
+		   AND ($3 = '' OR user_ids @> ARRAY[$3::varchar])
+		 ORDER BY id
+		 LIMIT $4`,
+		filter.AfterID, filter.TeamName, filter.UserID, filter.Limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query pull request events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*models.PullRequestEvent
+	for rows.Next() {
+		var (
+			event models.PullRequestEvent
+			id    int64
+			teams []string
+			data  []byte
+			at    time.Time
+		)
+		if err := rows.Scan(&id, &teams, &data, &at); err != nil {
+			return nil, fmt.Errorf("scan pull request event: %w", err)
+		}
+		if err := json.Unmarshal(data, &event); err != nil {
+			return nil, fmt.Errorf("decode pull request event: %w", err)
+		}
+		event.ID, event.Teams, event.CreatedAt = id, teams, at
+		events = append(events, &event)
+	}
+
+	return events, rows.Err()
+}
+
+// LastPullRequestEventID - id последнего события, 0 если событий нет
+func (r *Repository) LastPullRequestEventID(ctx context.Context) (int64, error) {
+	var id int64
+	err := r.db.QueryRow(ctx, "SELECT COALESCE(MAX(id), 0) FROM pr_events").Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("query last pull request event: %w", err)
+	}
+	return id, nil
+}
+
+// Idempotency keys
+
+// ReserveIdempotencyKey занимает ключ за запросом с хешем requestHash.
+// Ключ, чей срок истек или чей запрос завис дольше lockTimeout, занимается заново.
+// Если ключ занят, возвращается существующая запись и reserved = false.
+func (r *Repository) ReserveIdempotencyKey(ctx context.Context, key, requestHash string, ttl, lockTimeout time.Duration) (*models.IdempotencyRecord, bool, error) {
+	tag, err := r.db.Exec(ctx,
+		`INSERT INTO idempotency_keys (key, request_hash, expires_at)
+		 VALUES ($1, $2, NOW() + $3::interval)
+		 ON CONFLICT (key) DO UPDATE SET
+		     request_hash = EXCLUDED.request_hash,
+		     status_code = NULL,
+		     response_headers = NULL,
+		     response_body = NULL,
+		     created_at = NOW(),
+		     locked_at = NOW(),
+		     expires_at = EXCLUDED.expires_at
+		 WHERE idempotency_keys.expires_at <= NOW()
+		    OR (idempotency_keys.status_code IS NULL
+		        AND COALESCE(idempotency_keys.locked_at, idempotency_keys.created_at) <= NOW() - $4::interval)`,
+		key, requestHash, ttl, lockTimeout,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("reserve idempotency key: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		return nil, true, nil
+	}
+
+	record := &models.IdempotencyRecord{Key: key}
+	var (
+		statusCode *int
+		headers    []byte
+	)
+	err = r.db.QueryRow(ctx,
+		`SELECT request_hash, status_code, response_headers, response_body, created_at, expires_at
+		 FROM idempotency_keys WHERE key = $1`,
+		key,
+	).Scan(&record.RequestHash, &statusCode, &headers, &record.Body, &record.CreatedAt, &record.ExpiresAt)
+	if err != nil {
+		return nil, false, fmt.Errorf("get idempotency key: %w", err)
+	}
+
+	if statusCode != nil {
+		record.StatusCode = *statusCode
+	}
+	if headers != nil {
+		if err := json.Unmarshal(headers, &record.Headers); err != nil {
+			return nil, false, fmt.Errorf("decode idempotency response headers: %w", err)
+		}
+	}
+
+	return record, false, nil
+}
+
+// TouchIdempotencyKey продлевает блокировку ключа, запрос по которому еще выполняется
+func (r *Repository) TouchIdempotencyKey(ctx context.Context, key string) error {
+	_, err := r.db.Exec(ctx,
+		"UPDATE idempotency_keys SET locked_at = NOW() WHERE key = $1 AND status_code IS NULL",
+		key,
+	)
+	if err != nil {
+		return fmt.Errorf("touch idempotency key: %w", err)
+	}
+	return nil
+}
+
+// CompleteIdempotencyKey сохраняет ответ на запрос, занявший ключ
+func (r *Repository) CompleteIdempotencyKey(ctx context.Context, key string, statusCode int, headers map[string]string, body []byte) error {
+	data, err := json.Marshal(headers)
+	if err != nil {
+		return fmt.Errorf("encode idempotency response headers: %w", err)
+	}
+
+	_, err = r.db.Exec(ctx,
+		`UPDATE idempotency_keys
+		 SET status_code = $2, response_headers = $3, response_body = $4
+		 WHERE key = $1`,
+		key, statusCode, data, body,
+	)
+	if err != nil {
+		return fmt.Errorf("complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey освобождает ключ незавершенного запроса, чтобы его можно было повторить
+func (r *Repository) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM idempotency_keys WHERE key = $1 AND status_code IS NULL", key)
+	if err != nil {
+		return fmt.Errorf("release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpiredIdempotencyKeys удаляет ключи с истекшим сроком
+func (r *Repository) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	tag, err := r.db.Exec(ctx, "DELETE FROM idempotency_keys WHERE expires_at <= NOW()")
+	if err != nil {
+		return 0, fmt.Errorf("delete expired idempotency keys: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// Pending assignments
+func (r *Repository) DeletePendingAssignment(ctx context.Context, prID string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM pending_assignments WHERE pr_id = $1", prID)
+	if err != nil {
+		return fmt.Errorf("delete pending assignment: %w", err)
+	}
+	return nil
+}
+
+// GetPendingAssignments возвращает очередь открытых PR, старые первыми
+func (r *Repository) GetPendingAssignments(ctx context.Context) ([]*models.PendingAssignment, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT pa.pr_id, pa.desired_count, pa.queued_at, pa.attempts, pa.last_attempt_at
+		 FROM pending_assignments pa
+		 JOIN pull_requests pr ON pr.id = pa.pr_id
+		 WHERE pr.status = 'OPEN'
+		 ORDER BY pa.queued_at, pa.pr_id`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query pending assignments: %w", err)
+	}
+	defer rows.Close()
+
+	var pending []*models.PendingAssignment
+	for rows.Next() {
+		var pa models.PendingAssignment
+		err := rows.Scan(&pa.PullRequestID, &pa.DesiredCount, &pa.QueuedAt, &pa.Attempts, &pa.LastAttemptAt)
+		if err != nil {
+			return nil, fmt.Errorf("scan pending assignment: %w", err)
+		}
+		pending = append(pending, &pa)
+	}
+
+	return pending, rows.Err()
+}
+
+func (r *Repository) MarkPendingAssignmentAttempt(ctx context.Context, prID string) error {
+	_, err := r.db.Exec(ctx,
+		"UPDATE pending_assignments SET attempts = attempts + 1, last_attempt_at = NOW() WHERE pr_id = $1",
+		prID,
+	)
+	if err != nil {
+		return fmt.Errorf("mark pending assignment attempt: %w", err)
+	}
+	return nil
+}
+
+// GetUnderstaffedPullRequests - открытые PR из очереди с текущим количеством ревьюеров
+func (r *Repository) GetUnderstaffedPullRequests(ctx context.Context) ([]*models.UnderstaffedPullRequest, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT pr.id, pr.title, pr.author_id, pr.status,
+		        pa.desired_count, pa.queued_at, pa.attempts, pa.last_attempt_at,
+		        (SELECT COUNT(*) FROM pr_reviewers prr WHERE prr.pr_id = pr.id)
+		 FROM pending_assignments pa
+		 JOIN pull_requests pr ON pr.id = pa.pr_id
+		 WHERE pr.status = 'OPEN'
+		 ORDER BY pa.queued_at, pa.pr_id`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query understaffed pull requests: %w", err)
+	}
+	defer rows.Close()
+
+	var prs []*models.UnderstaffedPullRequest
+	for rows.Next() {
+		var pr models.UnderstaffedPullRequest
+		err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status,
+			&pr.DesiredCount, &pr.QueuedAt, &pr.Attempts, &pr.LastAttemptAt, &pr.AssignedCount)
+		if err != nil {
+			return nil, fmt.Errorf("scan understaffed pull request: %w", err)
+		}
+		prs = append(prs, &pr)
+	}
+
+	return prs, rows.Err()
+}
+
+// Repositories
+func (r *Repository) CreateRepository(ctx context.Context, repo *models.Repository) error {
+	_, err := r.db.Exec(ctx,
+		`INSERT INTO repositories (name, default_reviewer_count, team_id)
+		 VALUES ($1, $2, (SELECT id FROM teams WHERE name = $3))`,
+		repo.Name, repo.DefaultReviewerCount, repo.TeamName,
+	)
+	if err != nil {
+		return fmt.Errorf("insert repository: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetRepository(ctx context.Context, name string) (*models.Repository, error) {
+	var repo models.Repository
+	err := r.db.QueryRow(ctx,
+		`SELECT r.name, r.default_reviewer_count, COALESCE(t.name, ''), r.created_at
+		 FROM repositories r
+		 LEFT JOIN teams t ON r.team_id = t.id
+		 WHERE r.name = $1`,
+		name,
+	).Scan(&repo.Name, &repo.DefaultReviewerCount, &repo.TeamName, &repo.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("repository not found")
+		}
+		return nil, fmt.Errorf("query repository: %w", err)
+	}
+
+	return &repo, nil
+}
+
+func (r *Repository) ListRepositories(ctx context.Context) ([]*models.Repository, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT r.name, r.default_reviewer_count, COALESCE(t.name, ''), r.created_at
+		 FROM repositories r
+		 LEFT JOIN teams t ON r.team_id = t.id
+		 ORDER BY r.name`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query repositories: %w", err)
+	}
+	defer rows.Close()
+
+	var repos []*models.Repository
+	for rows.Next() {
+		var repo models.Repository
+		err := rows.Scan(&repo.Name, &repo.DefaultReviewerCount, &repo.TeamName, &repo.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("scan repository: %w", err)
+		}
+		repos = append(repos, &repo)
+	}
+
+	return repos, rows.Err()
+}
+
+func (r *Repository) UpdateRepository(ctx context.Context, repo *models.Repository) error {
+	_, err := r.db.Exec(ctx,
+		`UPDATE repositories
+		 SET default_reviewer_count = $1, team_id = (SELECT id FROM teams WHERE name = $2)
+		 WHERE name = $3`,
+		repo.DefaultReviewerCount, repo.TeamName, repo.Name,
+	)
+	if err != nil {
+		return fmt.Errorf("update repository: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) DeleteRepository(ctx context.Context, name string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM repositories WHERE name = $1", name)
+	if err != nil {
+		return fmt.Errorf("delete repository: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) RepositoryExists(ctx context.Context, name string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx,
+		"SELECT EXISTS(SELECT 1 FROM repositories WHERE name = $1)",
+		name,
+	).Scan(&exists)
+	return exists, err
+}
+
+func (r *Repository) RepositoryHasPullRequests(ctx context.Context, name string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx,
+		"SELECT EXISTS(SELECT 1 FROM pull_requests WHERE repository = $1)",
+		name,
+	).Scan(&exists)
+	return exists, err
+}
+
+// Out of office
+func (r *Repository) CreateOutOfOffice(ctx context.Context, ooo *models.OutOfOffice) error {
+	err := r.db.QueryRow(ctx,
+		`INSERT INTO user_out_of_office (user_id, starts_at, ends_at, reason)
+		 VALUES ($1, $2, $3, NULLIF($4, ''))
+		 RETURNING id`,
+		ooo.UserID, ooo.StartsAt, ooo.EndsAt, ooo.Reason,
+	).Scan(&ooo.ID)
+	if err != nil {
+		return fmt.Errorf("insert out of office: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) DeleteOutOfOffice(ctx context.Context, id string) (bool, error) {
+	tag, err := r.db.Exec(ctx, "DELETE FROM user_out_of_office WHERE id = $1", id)
+	if err != nil {
+		return false, fmt.Errorf("delete out of office: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *Repository) GetOutOfOfficeByUser(ctx context.Context, userID string) ([]*models.OutOfOffice, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT id, user_id, starts_at, ends_at, COALESCE(reason, ''), COALESCE(external_uid, '')
+		 FROM user_out_of_office
+		 WHERE user_id = $1
+		 ORDER BY starts_at`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query out of office: %w", err)
+	}
+	defer rows.Close()
+
+	var periods []*models.OutOfOffice
+	for rows.Next() {
+		var ooo models.OutOfOffice
+		err := rows.Scan(&ooo.ID, &ooo.UserID, &ooo.StartsAt, &ooo.EndsAt, &ooo.Reason, &ooo.ExternalUID)
+		if err != nil {
+			return nil, fmt.Errorf("scan out of office: %w", err)
+		}
+		periods = append(periods, &ooo)
+	}
+
+	return periods, rows.Err()
+}
+
+// SyncOutOfOfficeByUID приводит периоды события календаря externalUID к periods одной
+// транзакцией: периоды создаются или обновляются по (user_id, external_uid), а периоды
+// участников, которых в событии больше нет, удаляются. Возвращает число созданных
+// и удаленных периодов.
+func (r *Repository) SyncOutOfOfficeByUID(ctx context.Context, externalUID string, periods []*models.OutOfOffice) (int, int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	created := 0
+	userIDs := make([]string, 0, len(periods))
+	for _, ooo := range periods {
+		var inserted bool
+		err := tx.QueryRow(ctx,
+			`INSERT INTO user_out_of_office (user_id, starts_at, ends_at, reason, external_uid)
+			 VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+			 ON CONFLICT (user_id, external_uid) DO UPDATE
+			 SET starts_at = EXCLUDED.starts_at, ends_at = EXCLUDED.ends_at, reason = EXCLUDED.reason
+			 RETURNING id, (xmax = 0)`,
+			ooo.UserID, ooo.StartsAt, ooo.EndsAt, ooo.Reason, externalUID,
+		).Scan(&ooo.ID, &inserted)
+		if err != nil {
+			return 0, 0, fmt.Errorf("upsert out of office %s: %w", externalUID, err)
+		}
+		if inserted {
+			created++
+		}
+		userIDs = append(userIDs, ooo.UserID)
+	}
+
+	tag, err := tx.Exec(ctx,
+		"DELETE FROM user_out_of_office WHERE external_uid = $1 AND user_id <> ALL($2::varchar[])",
+		externalUID, userIDs,
+	)
+	if err != nil {
+		return 0, 0, fmt.Errorf("remove out of office %s: %w", externalUID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, 0, err
+	}
+	return created, tag.RowsAffected(), nil
+}
+
+func (r *Repository) DeleteOutOfOfficeByUID(ctx context.Context, externalUID string) (int64, error) {
+	tag, err := r.db.Exec(ctx, "DELETE FROM user_out_of_office WHERE external_uid = $1", externalUID)
+	if err != nil {
+		return 0, fmt.Errorf("delete out of office %s: %w", externalUID, err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// GetAwayUsers возвращает пользователей с периодами отсутствия, пересекающими [from, to)
+func (r *Repository) GetAwayUsers(ctx context.Context, from, to time.Time) ([]*models.AwayUser, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT `+userColumns+`, t.name,
+		        o.id, o.starts_at, o.ends_at, COALESCE(o.reason, '')
+		 FROM user_out_of_office o
+		 JOIN users u ON o.user_id = u.id
+		 JOIN teams t ON u.team_id = t.id
+		 WHERE o.starts_at < $2 AND o.ends_at > $1
+		 ORDER BY u.id, o.starts_at`,
+		from, to,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query away users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*models.AwayUser
+	for rows.Next() {
+		var user models.User
+		var ooo models.OutOfOffice
+		err := rows.Scan(append(userFields(&user), &user.TeamName,
+			&ooo.ID, &ooo.StartsAt, &ooo.EndsAt, &ooo.Reason)...)
+		if err != nil {
+			return nil, fmt.Errorf("scan away user: %w", err)
+		}
+		ooo.UserID = user.UserID
+
+		if len(users) == 0 || users[len(users)-1].UserID != user.UserID {
+			users = append(users, &models.AwayUser{User: user})
+		}
+		last := users[len(users)-1]
+		last.Periods = append(last.Periods, ooo)
+	}
+
+	return users, rows.Err()
+}
+
+// GetAwayUserIDs возвращает тех из userIDs, кто отсутствует в момент at
+func (r *Repository) GetAwayUserIDs(ctx context.Context, userIDs []string, at time.Time) (map[string]bool, error) {
+	away := make(map[string]bool)
+	if len(userIDs) == 0 {
+		return away, nil
+	}
+
+	rows, err := r.db.Query(ctx,
+		`SELECT DISTINCT user_id
+		 FROM user_out_of_office
+		 WHERE user_id = ANY($1) AND starts_at <= $2 AND ends_at > $2`,
+		userIDs, at,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query away user ids: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("scan away user id: %w", err)
+		}
+		away[userID] = true
+	}
+
+	return away, rows.Err()
+}
+
+// Assignment rules
+func (r *Repository) CreateAssignmentRule(ctx context.Context, rule *models.AssignmentRule) error {
+	err := r.db.QueryRow(ctx,
+		`INSERT INTO assignment_rules (kind, author_id, reviewer_id, reason)
+		 VALUES ($1, $2, $3, NULLIF($4, ''))
+		 RETURNING id, created_at`,
+		rule.Kind, rule.AuthorID, rule.ReviewerID, rule.Reason,
+	).Scan(&rule.ID, &rule.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert assignment rule: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) DeleteAssignmentRule(ctx context.Context, id string) (bool, error) {
+	tag, err := r.db.Exec(ctx, "DELETE FROM assignment_rules WHERE id = $1", id)
+	if err != nil {
+		return false, fmt.Errorf("delete assignment rule: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *Repository) AssignmentRuleExists(ctx context.Context, kind, authorID, reviewerID string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx,
+		"SELECT EXISTS(SELECT 1 FROM assignment_rules WHERE kind = $1 AND author_id = $2 AND reviewer_id = $3)",
+		kind, authorID, reviewerID,
+	).Scan(&exists)
+	return exists, err
+}
+
+// GetAssignmentRules возвращает правила автора authorID, а при пустом authorID - все правила
+func (r *Repository) GetAssignmentRules(ctx context.Context, authorID string) ([]*models.AssignmentRule, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT id, kind, author_id, reviewer_id, COALESCE(reason, ''), created_at
+		 FROM assignment_rules
+		 WHERE $1 = '' OR author_id = $1
+		 ORDER BY author_id, kind, reviewer_id`,
+		authorID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query assignment rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []*models.AssignmentRule
+	for rows.Next() {
+		var rule models.AssignmentRule
+		err := rows.Scan(&rule.ID, &rule.Kind, &rule.AuthorID, &rule.ReviewerID, &rule.Reason, &rule.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("scan assignment rule: %w", err)
+		}
+		rules = append(rules, &rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// Codeowners
+func (r *Repository) GetCodeowners(ctx context.Context, repository string) (string, error) {
+	var content string
+	err := r.db.QueryRow(ctx,
+		"SELECT content FROM codeowners WHERE repository = $1",
+		repository,
+	).Scan(&content)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", fmt.Errorf("codeowners not found")
+		}
+		return "", fmt.Errorf("query codeowners: %w", err)
+	}
+
+	return content, nil
+}
+
+func (r *Repository) SetCodeowners(ctx context.Context, repository, content string) error {
+	_, err := r.db.Exec(ctx,
+		`INSERT INTO codeowners (repository, content) VALUES ($1, $2)
+		 ON CONFLICT (repository) DO UPDATE SET content = EXCLUDED.content, updated_at = NOW()`,
+		repository, content,
+	)
+	if err != nil {
+		return fmt.Errorf("upsert codeowners: %w", err)
+	}
+	return nil
+}
+
+// Expertise
+func (r *Repository) attachExpertise(ctx context.Context, users []*models.User) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(users))
+	byID := make(map[string]*models.User, len(users))
+	for i, user := range users {
+		ids[i] = user.UserID
+		byID[user.UserID] = user
+	}
+
+	rows, err := r.db.Query(ctx,
+		"SELECT user_id, pattern FROM user_expertise WHERE user_id = ANY($1) ORDER BY pattern",
+		ids,
+	)
+	if err != nil {
+		return fmt.Errorf("query expertise: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID, pattern string
+		if err := rows.Scan(&userID, &pattern); err != nil {
+			return fmt.Errorf("scan expertise: %w", err)
+		}
+		if user, ok := byID[userID]; ok {
+			user.Expertise = append(user.Expertise, pattern)
+		}
+	}
+
+	return rows.Err()
+}
+
+func replaceExpertise(ctx context.Context, tx pgx.Tx, userID string, expertise []string) error {
+	_, err := tx.Exec(ctx, "DELETE FROM user_expertise WHERE user_id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("clear expertise %s: %w", userID, err)
+	}
+
+	for _, pattern := range expertise {
+		_, err = tx.Exec(ctx,
+			"INSERT INTO user_expertise (user_id, pattern) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+			userID, pattern,
+		)
+		if err != nil {
+			return fmt.Errorf("insert expertise %s: %w", userID, err)
+		}
+	}
+
+	return nil
+}
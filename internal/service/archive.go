@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"prmanager/internal/models"
+	"time"
+)
+
+// ExportArchive выгружает команды, пользователей, репозитории, PR и настройки
+func (s *Service) ExportArchive(ctx context.Context) (*models.Archive, error) {
+	s.info.Printf("Exporting archive")
+
+	archive, err := s.repo.ExportArchive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	archive.Version = models.ArchiveVersion
+	archive.ExportedAt = time.Now().UTC()
+
+	return archive, nil
+}
+
+// RestoreArchive восстанавливает архив в пустую базу. Архив целиком проверяется
+// до записи и записывается одной транзакцией.
+func (s *Service) RestoreArchive(ctx context.Context, archive *models.Archive) (*models.ArchiveSummary, error) {
+	s.info.Printf("Restoring archive version %d", archive.Version)
+
+	if archive.Version != models.ArchiveVersion {
+		return nil, errors.New("UNSUPPORTED_ARCHIVE_VERSION")
+	}
+	if err := validateArchive(archive); err != nil {
+		s.logger.Printf("Invalid archive: %v", err)
+		return nil, errors.New("INVALID_ARCHIVE")
+	}
+
+	empty, err := s.repo.IsEmpty(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !empty {
+		return nil, errors.New("DATABASE_NOT_EMPTY")
+	}
+
+	if err := s.repo.RestoreArchive(ctx, archive); err != nil {
+		return nil, err
+	}
+
+	// Восстановленная очередь на добор разбирается сразу
+	s.notifyPendingAssignments()
+
+	return &models.ArchiveSummary{
+		Teams:           len(archive.Teams),
+		Users:           len(archive.Users),
+		Repositories:    len(archive.Repositories),
+		PullRequests:    len(archive.PullRequests),
+		OutOfOffice:     len(archive.OutOfOffice),
+		AssignmentRules: len(archive.AssignmentRules),
+	}, nil
+}
+
+// validateArchive проверяет ссылки между сущностями архива и значения,
+// которые иначе отклонила бы база. Пустое время создания заменяется текущим.
+func validateArchive(archive *models.Archive) error {
+	now := time.Now()
+
+	teams := make(map[string]bool, len(archive.Teams))
+	for _, team := range archive.Teams {
+		if team.TeamName == "" || teams[team.TeamName] {
+			return fmt.Errorf("team %q is empty or duplicated", team.TeamName)
+		}
+		if !validStrategy(team.AssignmentStrategy) {
+			return fmt.Errorf("team %s: unknown assignment strategy %q", team.TeamName, team.AssignmentStrategy)
+		}
+		teams[team.TeamName] = true
+	}
+
+	users := make(map[string]bool, len(archive.Users))
+	for _, user := range archive.Users {
+		if user.UserID == "" || users[user.UserID] {
+			return fmt.Errorf("user %q is empty or duplicated", user.UserID)
+		}
+		if !teams[user.TeamName] {
+			return fmt.Errorf("user %s: team %q not found", user.UserID, user.TeamName)
+		}
+		if user.ReviewWeight < 0 || (user.MaxOpenReviews != nil && *user.MaxOpenReviews < 0) {
+			return fmt.Errorf("user %s: negative review weight or max open reviews", user.UserID)
+		}
+		users[user.UserID] = true
+	}
+
+	for i := range archive.Teams {
+		team := &archive.Teams[i]
+		if team.RoundRobinLastUserID != "" && !users[team.RoundRobinLastUserID] {
+			return fmt.Errorf("team %s: round robin user %q not found", team.TeamName, team.RoundRobinLastUserID)
+		}
+		if team.CreatedAt.IsZero() {
+			team.CreatedAt = now
+		}
+	}
+
+	repos := make(map[string]bool, len(archive.Repositories))
+	for i := range archive.Repositories {
+		repo := &archive.Repositories[i]
+		if repo.Name == "" || repos[repo.Name] {
+			return fmt.Errorf("repository %q is empty or duplicated", repo.Name)
+		}
+		if repo.TeamName != "" && !teams[repo.TeamName] {
+			return fmt.Errorf("repository %s: team %q not found", repo.Name, repo.TeamName)
+		}
+		if repo.DefaultReviewerCount < 0 {
+			return fmt.Errorf("repository %s: negative default reviewer count", repo.Name)
+		}
+		if repo.CreatedAt.IsZero() {
+			repo.CreatedAt = now
+		}
+		repos[repo.Name] = true
+	}
+
+	prs := make(map[string]bool, len(archive.PullRequests))
+	for i := range archive.PullRequests {
+		pr := &archive.PullRequests[i]
+		if pr.PullRequestID == "" || prs[pr.PullRequestID] {
+			return fmt.Errorf("pull request %q is empty or duplicated", pr.PullRequestID)
+		}
+		if !users[pr.AuthorID] {
+			return fmt.Errorf("pull request %s: author %q not found", pr.PullRequestID, pr.AuthorID)
+		}
+		if pr.Status != "OPEN" && pr.Status != "MERGED" {
+			return fmt.Errorf("pull request %s: unknown status %q", pr.PullRequestID, pr.Status)
+		}
+		if pr.Repository != "" && !repos[pr.Repository] {
+			return fmt.Errorf("pull request %s: repository %q not found", pr.PullRequestID, pr.Repository)
+		}
+		if pr.CreatedAt.IsZero() {
+			pr.CreatedAt = now
+		}
+
+		for j := range pr.Reviewers {
+			reviewer := &pr.Reviewers[j]
+			if !users[reviewer.UserID] {
+				return fmt.Errorf("pull request %s: reviewer %q not found", pr.PullRequestID, reviewer.UserID)
+			}
+			if reviewer.AssignedAt.IsZero() {
+				reviewer.AssignedAt = pr.CreatedAt
+			}
+		}
+		for j := range pr.ShadowReviewers {
+			shadow := &pr.ShadowReviewers[j]
+			if !users[shadow.UserID] || (shadow.MentorID != "" && !users[shadow.MentorID]) {
+				return fmt.Errorf("pull request %s: shadow reviewer %q or mentor not found", pr.PullRequestID, shadow.UserID)
+			}
+			if shadow.AssignedAt.IsZero() {
+				shadow.AssignedAt = pr.CreatedAt
+			}
+		}
+		if pending := pr.PendingAssignment; pending != nil && pending.QueuedAt.IsZero() {
+			pending.QueuedAt = pr.CreatedAt
+		}
+
+		prs[pr.PullRequestID] = true
+	}
+
+	for _, ooo := range archive.OutOfOffice {
+		if !users[ooo.UserID] {
+			return fmt.Errorf("out of office %s: user %q not found", ooo.ID, ooo.UserID)
+		}
+		if !ooo.EndsAt.After(ooo.StartsAt) {
+			return fmt.Errorf("out of office %s: period ends before it starts", ooo.ID)
+		}
+	}
+
+	for i := range archive.AssignmentRules {
+		rule := &archive.AssignmentRules[i]
+		if rule.Kind != models.RuleExclude && rule.Kind != models.RulePrefer {
+			return fmt.Errorf("assignment rule %s: unknown kind %q", rule.ID, rule.Kind)
+		}
+		if !users[rule.AuthorID] || !users[rule.ReviewerID] || rule.AuthorID == rule.ReviewerID {
+			return fmt.Errorf("assignment rule %s: author or reviewer not found or equal", rule.ID)
+		}
+		if rule.CreatedAt.IsZero() {
+			rule.CreatedAt = now
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"path"
+	"prmanager/internal/models"
+	"prmanager/internal/pathmatch"
+	"sort"
+	"strings"
+)
+
+// selection - состояние подбора ревьюеров для одного PR
+type selection struct {
+	rng      *rand.Rand
+	authorID string
+	files    []string
+	rules    *authorRules
+	// trace - куда записывать решения по кандидатам; nil - не записывать
+	trace *assignmentTrace
+	// batch - назначения пакета импорта, еще не записанные в БД; nil вне импорта
+	batch *assignmentBatch
+	// advance - сдвиг курсора round_robin, записывается вместе с назначением
+	advance *models.RoundRobinAdvance
+	// strategy - стратегия, которой выбраны ревьюеры (заполняет pickReviewers)
+	strategy string
+}
+
+// assignmentBatch - назначения пакета импорта, которые еще не записаны в БД:
+// подбор должен видеть их так же, как уже сохраненные PR
+type assignmentBatch struct {
+	// openReviews - сколько открытых PR пакета назначено на ревьюера
+	openReviews map[string]int
+	// cursors - курсор round_robin команды после уже подобранных PR пакета
+	cursors map[string]*string
+}
+
+func newAssignmentBatch() *assignmentBatch {
+	return &assignmentBatch{
+		openReviews: make(map[string]int),
+		cursors:     make(map[string]*string),
+	}
+}
+
+// add учитывает подобранный открытый PR: нагрузку его ревьюеров и сдвиг курсора
+func (b *assignmentBatch) add(reviewerIDs []string, advance *models.RoundRobinAdvance) {
+	for _, userID := range reviewerIDs {
+		b.openReviews[userID]++
+	}
+	if advance != nil {
+		to := advance.To
+		b.cursors[advance.TeamName] = &to
+	}
+}
+
+// cursor возвращает курсор round_robin команды, сдвинутый пакетом; ok=false - пакет
+// команду не трогал (или подбор идет вне импорта)
+func (b *assignmentBatch) cursor(teamName string) (lastUserID *string, ok bool) {
+	if b == nil {
+		return nil, false
+	}
+	lastUserID, ok = b.cursors[teamName]
+	return lastUserID, ok
+}
+
+// openReviewCount - открытые ревью пользователя в пакете, еще не записанные в БД
+func (b *assignmentBatch) openReviewCount(userID string) int {
+	if b == nil {
+		return 0
+	}
+	return b.openReviews[userID]
+}
+
+func (s *Service) newSelection(ctx context.Context, authorID string, files []string, rng *rand.Rand) (*selection, error) {
+	rules, err := s.loadAuthorRules(ctx, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &selection{
+		rng:      rng,
+		authorID: authorID,
+		files:    files,
+		rules:    rules,
+	}, nil
+}
+
+// pickReviewers дополняет preassigned до count по стратегии команды teamName:
+// round_robin - строго по кругу, random - взвешенно-случайно с учетом экспертизы
+func (s *Service) pickReviewers(ctx context.Context, sel *selection, teamName string, teamUsers []*models.User, preassigned []string, count int) ([]string, error) {
+	strategy, err := s.repo.GetTeamStrategy(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if strategy != models.StrategyRoundRobin {
+		sel.strategy = models.StrategyRandom
+		return s.autoAssignReviewers(sel, teamUsers, preassigned, count), nil
+	}
+	sel.strategy = models.StrategyRoundRobin
+
+	reviewerIDs := append([]string{}, preassigned...)
+	if count-len(reviewerIDs) <= 0 {
+		return reviewerIDs, nil
+	}
+
+	taken := make(map[string]bool, len(preassigned))
+	for _, id := range preassigned {
+		taken[id] = true
+	}
+
+	var candidates []string
+	for _, user := range teamUsers {
+		if user.UserID != sel.authorID && user.IsActive && !taken[user.UserID] {
+			candidates = append(candidates, user.UserID)
+		}
+	}
+
+	// Внутри пакета импорта курсор продолжается с последнего подобранного PR
+	lastUserID, ok := sel.batch.cursor(teamName)
+	if !ok {
+		lastUserID, err = s.repo.GetRoundRobinCursor(ctx, teamName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	picked := roundRobinPick(candidates, lastUserID, count-len(reviewerIDs))
+	if len(picked) > 0 {
+		sel.advance = &models.RoundRobinAdvance{
+			TeamName: teamName,
+			From:     lastUserID,
+			To:       picked[len(picked)-1],
+		}
+	}
+	for _, userID := range picked {
+		sel.trace.note(userID, "next in round-robin order")
+	}
+
+	return append(reviewerIDs, picked...), nil
+}
+
+// roundRobinPick берет count кандидатов по возрастанию id, начиная после lastUserID
+func roundRobinPick(candidates []string, lastUserID *string, count int) []string {
+	ordered := append([]string{}, candidates...)
+	sort.Strings(ordered)
+
+	// Первый кандидат после последнего назначенного
+	start := 0
+	if lastUserID != nil {
+		start = sort.SearchStrings(ordered, *lastUserID)
+		if start < len(ordered) && ordered[start] == *lastUserID {
+			start++
+		}
+	}
+
+	if count > len(ordered) {
+		count = len(ordered)
+	}
+	picked := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		picked = append(picked, ordered[(start+i)%len(ordered)])
+	}
+
+	return picked
+}
+
+// expertiseScore - количество измененных файлов, попадающих в экспертизу пользователя
+func expertiseScore(user *models.User, files []string) int {
+	if len(user.Expertise) == 0 || len(files) == 0 {
+		return 0
+	}
+
+	score := 0
+	for _, file := range files {
+		for _, entry := range user.Expertise {
+			if matchesExpertise(entry, file) {
+				score++
+				break
+			}
+		}
+	}
+
+	return score
+}
+
+// matchesExpertise - запись экспертизы без "/" и метасимволов считается тегом:
+// она совпадает с одноименным каталогом или расширением файла ("go" -> "*.go").
+func matchesExpertise(entry, file string) bool {
+	entry = strings.TrimSpace(entry)
+	if pathmatch.Match(entry, file) {
+		return true
+	}
+
+	if strings.Contains(entry, "/") || pathmatch.HasMeta(entry) {
+		return false
+	}
+	return strings.EqualFold(strings.TrimPrefix(path.Ext(file), "."), entry)
+}
+
+// rankCandidates упорядочивает кандидатов: сначала предпочтенные автором (PREFER),
+// затем по убыванию совпадения экспертизы с измененными файлами, а при равенстве -
+// взвешенно-случайно по ReviewWeight. Взять первых k из результата - то же, что выбрать
+// k кандидатов без возвращения с вероятностями, пропорциональными весам
+// (метод Efraimidis-Spirakis).
+func rankCandidates(sel *selection, candidates []*models.User) []*models.User {
+	ranked := make([]*models.User, len(candidates))
+	copy(ranked, candidates)
+
+	preferred := make(map[string]bool, len(ranked))
+	scores := make(map[string]int, len(ranked))
+	keys := make(map[string]float64, len(ranked))
+	for _, user := range ranked {
+		preferred[user.UserID] = sel.rules.isPreferred(user.UserID)
+		scores[user.UserID] = expertiseScore(user, sel.files)
+		keys[user.UserID] = weightedKey(sel.rng, user.ReviewWeight)
+
+		if preferred[user.UserID] {
+			sel.trace.note(user.UserID, "preferred by "+ruleReason(sel.rules.preferred[user.UserID]))
+		}
+		if scores[user.UserID] > 0 {
+			sel.trace.note(user.UserID, fmt.Sprintf("expertise matches %d of %d files", scores[user.UserID], len(sel.files)))
+		}
+		if user.ReviewWeight <= 0 {
+			sel.trace.note(user.UserID, "review weight 0, chosen last")
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i].UserID, ranked[j].UserID
+		if preferred[a] != preferred[b] {
+			return preferred[a]
+		}
+		if scores[a] != scores[b] {
+			return scores[a] > scores[b]
+		}
+		return keys[a] > keys[b]
+	})
+
+	sel.trace.rank(ranked)
+
+	return ranked
+}
+
+// weightedKey - случайный ключ u^(1/w): чем больше вес, тем ближе ключ к 1.
+// Пользователи с нулевым весом получают отрицательный ключ и выбираются последними.
+func weightedKey(rng *rand.Rand, weight float64) float64 {
+	u := rng.Float64()
+	if weight <= 0 {
+		return -1 - u
+	}
+	return math.Pow(u, 1/weight)
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"prmanager/internal/models"
+	"slices"
+	"testing"
+)
+
+// TestRankCandidatesWeights проверяет, что первым кандидатом выбирается
+// пользователь с вероятностью w_i / sum(w). Допуск 0.01 при 100000 выборах -
+// больше шести стандартных отклонений, поэтому результат не зависит от seed.
+func TestRankCandidatesWeights(t *testing.T) {
+	const (
+		trials    = 100000
+		tolerance = 0.01
+	)
+
+	tests := []struct {
+		name    string
+		weights []float64
+	}{
+		{"equal weights", []float64{1, 1, 1, 1}},
+		{"different weights", []float64{1, 2, 3, 4}},
+		{"fractional weights", []float64{0.5, 1, 2.5}},
+		{"zero weight", []float64{1, 0, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates := make([]*models.User, len(tt.weights))
+			total := 0.0
+			for i, weight := range tt.weights {
+				candidates[i] = &models.User{UserID: fmt.Sprintf("u%d", i), IsActive: true, ReviewWeight: weight}
+				total += weight
+			}
+
+			s := newTestService(WithRandSource(rand.NewSource(1)))
+			sel := &selection{rng: s.rng, rules: &authorRules{}}
+
+			firstPicks := make(map[string]int, len(candidates))
+			for i := 0; i < trials; i++ {
+				firstPicks[rankCandidates(sel, candidates)[0].UserID]++
+			}
+
+			for i, weight := range tt.weights {
+				want := weight / total
+				got := float64(firstPicks[candidates[i].UserID]) / trials
+				if math.Abs(got-want) > tolerance {
+					t.Errorf("weight %v: first pick frequency %.4f, want %.4f ± %.2f", weight, got, want, tolerance)
+				}
+				if weight == 0 && firstPicks[candidates[i].UserID] != 0 {
+					t.Errorf("weight 0 candidate was picked first %d times", firstPicks[candidates[i].UserID])
+				}
+			}
+		})
+	}
+}
+
+// Пользователь с нулевым весом все же назначается, если больше некого
+func TestRankCandidatesZeroWeightLast(t *testing.T) {
+	s := newTestService(WithRandSource(rand.NewSource(1)))
+	sel := &selection{rng: s.rng, rules: &authorRules{}}
+
+	candidates := []*models.User{
+		{UserID: "zero", IsActive: true, ReviewWeight: 0},
+		{UserID: "low", IsActive: true, ReviewWeight: 0.01},
+	}
+	for i := 0; i < 1000; i++ {
+		ranked := rankCandidates(sel, candidates)
+		if ranked[0].UserID != "low" || ranked[1].UserID != "zero" {
+			t.Fatalf("ranked %s, %s; want low, zero", ranked[0].UserID, ranked[1].UserID)
+		}
+	}
+}
+
+// TestAssignmentBatchRoundRobin проверяет, что PR пакета импорта продолжают
+// обход round_robin с места предыдущего PR, а не с курсора в БД, и что
+// их ревьюеры учитываются в нагрузке.
+func TestAssignmentBatchRoundRobin(t *testing.T) {
+	candidates := []string{"u3", "u1", "u2", "u4"}
+	batch := newAssignmentBatch()
+
+	var picks [][]string
+	for i := 0; i < 3; i++ {
+		// Курсора в БД нет: первый PR начинает обход с начала
+		from, _ := batch.cursor("backend")
+		picked := roundRobinPick(candidates, from, 2)
+		batch.add(picked, &models.RoundRobinAdvance{TeamName: "backend", From: from, To: picked[len(picked)-1]})
+		picks = append(picks, picked)
+	}
+
+	want := [][]string{{"u1", "u2"}, {"u3", "u4"}, {"u1", "u2"}}
+	for i := range want {
+		if !slices.Equal(picks[i], want[i]) {
+			t.Errorf("pick %d = %v, want %v", i, picks[i], want[i])
+		}
+	}
+	if got := batch.openReviewCount("u1"); got != 2 {
+		t.Errorf("openReviewCount(u1) = %d, want 2", got)
+	}
+	if _, ok := batch.cursor("frontend"); ok {
+		t.Error("cursor of untouched team must not be set")
+	}
+
+	var none *assignmentBatch
+	if got := none.openReviewCount("u1"); got != 0 {
+		t.Errorf("nil batch openReviewCount = %d, want 0", got)
+	}
+}
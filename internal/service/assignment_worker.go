@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"prmanager/internal/models"
+	"time"
+)
+
+// RunAssignmentWorker разбирает очередь PR без нужного числа ревьюеров.
+// Очередь обрабатывается по сигналу (пользователь стал активным, вступил в команду,
+// освободил место) и раз в interval - чтобы подхватить закончившиеся отпуска.
+// Работает до отмены ctx.
+func (s *Service) RunAssignmentWorker(ctx context.Context, interval time.Duration) {
+	s.info.Printf("Pending assignment worker started (interval %s)", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.info.Println("Pending assignment worker stopped")
+			return
+		case <-s.pendingSignal:
+		case <-ticker.C:
+		}
+
+		s.resolvePendingAssignments(ctx)
+	}
+}
+
+// notifyPendingAssignments будит воркер; повторные сигналы до обработки схлопываются
+func (s *Service) notifyPendingAssignments() {
+	select {
+	case s.pendingSignal <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Service) GetUnderstaffedPullRequests(ctx context.Context) ([]*models.UnderstaffedPullRequest, error) {
+	s.info.Println("Getting understaffed pull requests")
+
+	return s.repo.GetUnderstaffedPullRequests(ctx)
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"prmanager/internal/models"
+	"time"
+)
+
+// Out of office
+func (s *Service) AddOutOfOffice(ctx context.Context, ooo *models.OutOfOffice) (*models.OutOfOffice, error) {
+	s.info.Printf("Adding out of office for user %s: %s - %s", ooo.UserID, ooo.StartsAt, ooo.EndsAt)
+
+	if !ooo.EndsAt.After(ooo.StartsAt) {
+		return nil, errors.New("INVALID_PERIOD")
+	}
+
+	if _, err := s.repo.GetUser(ctx, ooo.UserID); err != nil {
+		return nil, errors.New("NOT_FOUND")
+	}
+
+	if err := s.repo.CreateOutOfOffice(ctx, ooo); err != nil {
+		return nil, err
+	}
+
+	return ooo, nil
+}
+
+func (s *Service) RemoveOutOfOffice(ctx context.Context, id string) error {
+	s.info.Printf("Removing out of office: %s", id)
+
+	deleted, err := s.repo.DeleteOutOfOffice(ctx, id)
+	if err != nil || !deleted {
+		return errors.New("NOT_FOUND")
+	}
+
+	s.notifyPendingAssignments()
+
+	return nil
+}
+
+func (s *Service) GetOutOfOffice(ctx context.Context, userID string) ([]*models.OutOfOffice, error) {
+	s.info.Printf("Getting out of office for user: %s", userID)
+
+	if _, err := s.repo.GetUser(ctx, userID); err != nil {
+		return nil, errors.New("NOT_FOUND")
+	}
+
+	return s.repo.GetOutOfOfficeByUser(ctx, userID)
+}
+
+// GetAwayUsers возвращает пользователей, отсутствующих хотя бы часть суток date
+func (s *Service) GetAwayUsers(ctx context.Context, date time.Time) ([]*models.AwayUser, error) {
+	s.info.Printf("Getting users away on %s", date.Format(time.DateOnly))
+
+	from := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	return s.repo.GetAwayUsers(ctx, from, from.AddDate(0, 0, 1))
+}
+
+// filterAvailable отбрасывает пользователей, находящихся сейчас в отпуске/вне офиса
+func (s *Service) filterAvailable(ctx context.Context, users []*models.User) ([]*models.User, error) {
+	if len(users) == 0 {
+		return users, nil
+	}
+
+	ids := make([]string, len(users))
+	for i, user := range users {
+		ids[i] = user.UserID
+	}
+
+	away, err := s.repo.GetAwayUserIDs(ctx, ids, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	var available []*models.User
+	for _, user := range users {
+		if !away[user.UserID] {
+			available = append(available, user)
+		}
+	}
+
+	return available, nil
+}
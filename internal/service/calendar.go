@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"prmanager/internal/ical"
+	"prmanager/internal/models"
+	"regexp"
+	"strings"
+)
+
+// maxExternalUIDLength - длина колонки user_out_of_office.external_uid
+const maxExternalUIDLength = 255
+
+var oooSummaryRe = regexp.MustCompile(`(?i)\bOOO\b`)
+
+// userLookup ищет пользователей по id или username (см. Repository.FindUsersByIdentity)
+type userLookup func(ctx context.Context, identity string) ([]*models.User, error)
+
+// calendarChange - изменение периодов отсутствия одного события календаря
+type calendarChange struct {
+	uid       string
+	cancelled bool
+	// periods - периоды всех сопоставленных участников события; периоды прочих
+	// пользователей с тем же UID удаляются
+	periods []*models.OutOfOffice
+}
+
+// ImportCalendar создает периоды отсутствия из событий календаря, помеченных как OOO.
+// Повторный импорт того же файла ничего не дублирует: периоды обновляются по UID события,
+// участники, удаленные из события, теряют свои периоды, а отмененные события
+// (STATUS:CANCELLED) удаляют ранее импортированные периоды.
+func (s *Service) ImportCalendar(ctx context.Context, r io.Reader) (*models.CalendarImportResult, error) {
+	s.info.Println("Importing out of office calendar")
+
+	events, err := ical.Parse(r)
+	if err != nil {
+		s.logger.Printf("Invalid calendar: %v", err)
+		return nil, errors.New("INVALID_CALENDAR")
+	}
+
+	changes, skipped, err := planCalendarImport(ctx, events, s.repo.FindUsersByIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.CalendarImportResult{Skipped: skipped}
+	for _, change := range changes {
+		if change.cancelled {
+			deleted, err := s.repo.DeleteOutOfOfficeByUID(ctx, change.uid)
+			if err != nil {
+				return nil, err
+			}
+			result.Cancelled += int(deleted)
+			continue
+		}
+
+		created, removed, err := s.repo.SyncOutOfOfficeByUID(ctx, change.uid, change.periods)
+		if err != nil {
+			return nil, err
+		}
+		result.Created += created
+		result.Updated += len(change.periods) - created
+		result.Removed += int(removed)
+	}
+
+	if result.Cancelled > 0 || result.Removed > 0 {
+		s.notifyPendingAssignments()
+	}
+
+	return result, nil
+}
+
+// planCalendarImport отбирает OOO-события и сопоставляет их участников с пользователями.
+// События, которые нельзя импортировать, попадают в skipped с причиной.
+func planCalendarImport(ctx context.Context, events []*ical.Event, lookup userLookup) ([]calendarChange, []models.CalendarImportSkip, error) {
+	var changes []calendarChange
+	skipped := []models.CalendarImportSkip{}
+	skip := func(uid, reason string) {
+		skipped = append(skipped, models.CalendarImportSkip{UID: uid, Reason: reason})
+	}
+
+	for _, event := range events {
+		if !isOutOfOfficeEvent(event) {
+			continue
+		}
+
+		if len(event.UID) > maxExternalUIDLength {
+			skip(event.UID, "UID is too long")
+			continue
+		}
+
+		if event.Status == "CANCELLED" {
+			changes = append(changes, calendarChange{uid: event.UID, cancelled: true})
+			continue
+		}
+
+		if event.UnknownTZID != "" {
+			skip(event.UID, fmt.Sprintf("unknown time zone %q", event.UnknownTZID))
+			continue
+		}
+		if event.Recurring {
+			skip(event.UID, "recurring events are not supported")
+			continue
+		}
+		if !event.End.After(event.Start) {
+			skip(event.UID, "event has no duration")
+			continue
+		}
+
+		users, err := calendarEventUsers(ctx, event, lookup)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(users) == 0 {
+			skip(event.UID, "no matching user")
+			continue
+		}
+
+		change := calendarChange{uid: event.UID}
+		for _, user := range users {
+			change.periods = append(change.periods, &models.OutOfOffice{
+				UserID:      user.UserID,
+				StartsAt:    event.Start,
+				EndsAt:      event.End,
+				Reason:      event.Summary,
+				ExternalUID: event.UID,
+			})
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, skipped, nil
+}
+
+// isOutOfOfficeEvent - событие с категорией OOO / Out of office, статусом OOF (Outlook)
+// или словом OOO в заголовке
+func isOutOfOfficeEvent(event *ical.Event) bool {
+	for _, category := range event.Categories {
+		if strings.EqualFold(category, "OOO") || strings.EqualFold(category, "Out of office") {
+			return true
+		}
+	}
+
+	if strings.EqualFold(event.Properties["X-MICROSOFT-CDO-BUSYSTATUS"], "OOF") {
+		return true
+	}
+
+	return oooSummaryRe.MatchString(event.Summary)
+}
+
+// calendarEventUsers сопоставляет участников события с пользователями по id или username:
+// сначала ATTENDEE, затем ORGANIZER. Учитываются CN и локальная часть e-mail.
+func calendarEventUsers(ctx context.Context, event *ical.Event, lookup userLookup) ([]*models.User, error) {
+	var users []*models.User
+	seen := make(map[string]bool)
+
+	people := event.Attendees
+	for attempt := 0; attempt < 2 && len(users) == 0; attempt++ {
+		if attempt == 1 {
+			if event.Organizer == nil {
+				break
+			}
+			people = []ical.Person{*event.Organizer}
+		}
+
+		for _, person := range people {
+			user, err := findCalendarUser(ctx, person, lookup)
+			if err != nil {
+				return nil, err
+			}
+			if user != nil && !seen[user.UserID] {
+				seen[user.UserID] = true
+				users = append(users, user)
+			}
+		}
+	}
+
+	return users, nil
+}
+
+func findCalendarUser(ctx context.Context, person ical.Person, lookup userLookup) (*models.User, error) {
+	identities := []string{person.Name}
+	if person.Email != "" {
+		local, _, _ := strings.Cut(person.Email, "@")
+		identities = append(identities, local, person.Email)
+	}
+
+	for _, identity := range identities {
+		if identity == "" {
+			continue
+		}
+
+		users, err := lookup(ctx, identity)
+		if err != nil {
+			return nil, err
+		}
+		// Неоднозначное совпадение по username пропускаем
+		if len(users) == 1 {
+			return users[0], nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"prmanager/internal/ical"
+	"prmanager/internal/models"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testLookup ищет пользователей так же, как FindUsersByIdentity: по id или username без учета регистра
+func testLookup(users ...*models.User) userLookup {
+	return func(_ context.Context, identity string) ([]*models.User, error) {
+		var found []*models.User
+		for _, user := range users {
+			if strings.EqualFold(user.UserID, identity) || strings.EqualFold(user.Username, identity) {
+				found = append(found, user)
+			}
+		}
+		return found, nil
+	}
+}
+
+func parseCalendar(t *testing.T, lines ...string) []*ical.Event {
+	t.Helper()
+
+	calendar := append([]string{"BEGIN:VCALENDAR"}, lines...)
+	calendar = append(calendar, "END:VCALENDAR")
+	events, err := ical.Parse(strings.NewReader(strings.Join(calendar, "\r\n")))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return events
+}
+
+func TestIsOutOfOfficeEvent(t *testing.T) {
+	tests := []struct {
+		name  string
+		event *ical.Event
+		want  bool
+	}{
+		{"category", &ical.Event{Categories: []string{"Work", "ooo"}}, true},
+		{"out of office category", &ical.Event{Categories: []string{"Out Of Office"}}, true},
+		{"outlook busy status", &ical.Event{Properties: map[string]string{"X-MICROSOFT-CDO-BUSYSTATUS": "oof"}}, true},
+		{"summary word", &ical.Event{Summary: "Vacation (OOO)"}, true},
+		{"summary lowercase", &ical.Event{Summary: "ooo: dentist"}, true},
+		{"summary substring", &ical.Event{Summary: "FOOOD truck"}, false},
+		{"busy", &ical.Event{Summary: "Planning", Properties: map[string]string{"X-MICROSOFT-CDO-BUSYSTATUS": "BUSY"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isOutOfOfficeEvent(tt.event); got != tt.want {
+				t.Errorf("isOutOfOfficeEvent = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendarEventUsers(t *testing.T) {
+	lookup := testLookup(
+		&models.User{UserID: "u1", Username: "Alice"},
+		&models.User{UserID: "u2", Username: "bob"},
+		&models.User{UserID: "u3", Username: "sam"},
+		&models.User{UserID: "u4", Username: "sam"},
+	)
+
+	tests := []struct {
+		name  string
+		event *ical.Event
+		want  []string
+	}{
+		{
+			name:  "name and email local part",
+			event: &ical.Event{Attendees: []ical.Person{{Name: "alice"}, {Email: "u2@example.com"}}},
+			want:  []string{"u1", "u2"},
+		},
+		{
+			name:  "duplicates",
+			event: &ical.Event{Attendees: []ical.Person{{Name: "Alice"}, {Email: "alice@example.com"}}},
+			want:  []string{"u1"},
+		},
+		{
+			name:  "ambiguous username falls back to email",
+			event: &ical.Event{Attendees: []ical.Person{{Name: "sam", Email: "u4@example.com"}}},
+			want:  []string{"u4"},
+		},
+		{
+			name: "organizer when no attendee matches",
+			event: &ical.Event{
+				Attendees: []ical.Person{{Name: "Room 101"}},
+				Organizer: &ical.Person{Email: "bob@example.com"},
+			},
+			want: []string{"u2"},
+		},
+		{
+			name: "organizer ignored when attendees match",
+			event: &ical.Event{
+				Attendees: []ical.Person{{Name: "alice"}},
+				Organizer: &ical.Person{Name: "bob"},
+			},
+			want: []string{"u1"},
+		},
+		{
+			name:  "no match",
+			event: &ical.Event{Attendees: []ical.Person{{Name: "sam"}}},
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, err := calendarEventUsers(context.Background(), tt.event, lookup)
+			if err != nil {
+				t.Fatalf("calendarEventUsers: %v", err)
+			}
+
+			var got []string
+			for _, user := range users {
+				got = append(got, user.UserID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("users = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanCalendarImportReimport(t *testing.T) {
+	lookup := testLookup(
+		&models.User{UserID: "u1", Username: "alice"},
+		&models.User{UserID: "u2", Username: "bob"},
+	)
+	event := func(status string, attendees ...string) []string {
+		lines := []string{
+			"BEGIN:VEVENT",
+			"UID:vacation-1",
+			"SUMMARY:OOO",
+			"DTSTART:20250310T090000Z",
+			"DTEND:20250314T180000Z",
+		}
+		if status != "" {
+			lines = append(lines, "STATUS:"+status)
+		}
+		for _, attendee := range attendees {
+			lines = append(lines, "ATTENDEE;CN="+attendee+":mailto:"+attendee+"@example.com")
+		}
+		return append(lines, "END:VEVENT")
+	}
+	plan := func(lines []string) calendarChange {
+		t.Helper()
+
+		changes, skipped, err := planCalendarImport(context.Background(), parseCalendar(t, lines...), lookup)
+		if err != nil {
+			t.Fatalf("planCalendarImport: %v", err)
+		}
+		if len(skipped) != 0 {
+			t.Fatalf("skipped = %v", skipped)
+		}
+		if len(changes) != 1 {
+			t.Fatalf("got %d changes, want 1", len(changes))
+		}
+		return changes[0]
+	}
+	periodUsers := func(change calendarChange) []string {
+		var userIDs []string
+		for _, period := range change.periods {
+			if period.ExternalUID != change.uid {
+				t.Errorf("period UID = %q, want %q", period.ExternalUID, change.uid)
+			}
+			userIDs = append(userIDs, period.UserID)
+		}
+		return userIDs
+	}
+
+	first := plan(event("", "alice", "bob"))
+	if got := periodUsers(first); !slices.Equal(got, []string{"u1", "u2"}) {
+		t.Errorf("first import periods = %v, want [u1 u2]", got)
+	}
+
+	// Повторный импорт описывает событие целиком: периоды bob с этим UID будут удалены
+	second := plan(event("CONFIRMED", "alice"))
+	if second.uid != first.uid || second.cancelled {
+		t.Errorf("second import = %+v, want sync of %q", second, first.uid)
+	}
+	if got := periodUsers(second); !slices.Equal(got, []string{"u1"}) {
+		t.Errorf("second import periods = %v, want [u1]", got)
+	}
+
+	cancelled := plan(event("CANCELLED", "alice"))
+	if !cancelled.cancelled || len(cancelled.periods) != 0 {
+		t.Errorf("cancelled import = %+v, want cancellation without periods", cancelled)
+	}
+}
+
+func TestPlanCalendarImportSkips(t *testing.T) {
+	lookup := testLookup(&models.User{UserID: "u1", Username: "alice"})
+	events := parseCalendar(t,
+		"BEGIN:VEVENT", "UID:meeting", "SUMMARY:Planning", "DTSTART:20250310T090000Z", "DTEND:20250310T100000Z", "ATTENDEE;CN=alice:mailto:alice@example.com", "END:VEVENT",
+		"BEGIN:VEVENT", "UID:zone", "SUMMARY:OOO", "DTSTART;TZID=Mars/Olympus_Mons:20250310T090000", "DTEND:20250310T100000Z", "ATTENDEE;CN=alice:mailto:alice@example.com", "END:VEVENT",
+		"BEGIN:VEVENT", "UID:empty", "SUMMARY:OOO", "DTSTART:20250310T090000Z", "DTEND:20250310T090000Z", "ATTENDEE;CN=alice:mailto:alice@example.com", "END:VEVENT",
+		"BEGIN:VEVENT", "UID:stranger", "SUMMARY:OOO", "DTSTART:20250310T090000Z", "DTEND:20250310T100000Z", "ATTENDEE;CN=carol:mailto:carol@example.com", "END:VEVENT",
+	)
+
+	changes, skipped, err := planCalendarImport(context.Background(), events, lookup)
+	if err != nil {
+		t.Fatalf("planCalendarImport: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("changes = %+v, want none", changes)
+	}
+
+	var uids []string
+	for _, skip := range skipped {
+		uids = append(uids, skip.UID)
+	}
+	if want := []string{"zone", "empty", "stranger"}; !slices.Equal(uids, want) {
+		t.Errorf("skipped = %v, want %v", uids, want)
+	}
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"prmanager/internal/models"
+	"prmanager/internal/repository"
+)
+
+func (s *Service) SetUserMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews *int) (*models.User, error) {
+	s.info.Printf("Setting max open reviews for user %s", userID)
+
+	if maxOpenReviews != nil && *maxOpenReviews < 0 {
+		return nil, errors.New("INVALID_REQUEST")
+	}
+
+	user, err := s.repo.SetUserMaxOpenReviews(ctx, userID, maxOpenReviews)
+	if err != nil {
+		return nil, errors.New("NOT_FOUND")
+	}
+
+	// Увеличение лимита могло освободить место для PR из очереди
+	s.notifyPendingAssignments()
+
+	return user, nil
+}
+
+// filterByCapacity отбрасывает пользователей, достигших лимита открытых ревью.
+// К записанным в БД ревью добавляются назначения batch, еще не сохраненные.
+// Возвращает оставшихся и количество отброшенных.
+func (s *Service) filterByCapacity(ctx context.Context, users []*models.User, batch *assignmentBatch) ([]*models.User, int, error) {
+	var limited []string
+	for _, user := range users {
+		if user.MaxOpenReviews != nil {
+			limited = append(limited, user.UserID)
+		}
+	}
+	if len(limited) == 0 {
+		return users, 0, nil
+	}
+
+	counts, err := s.repo.GetOpenReviewCounts(ctx, limited)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var available []*models.User
+	skipped := 0
+	for _, user := range users {
+		if user.MaxOpenReviews != nil && counts[user.UserID]+batch.openReviewCount(user.UserID) >= *user.MaxOpenReviews {
+			skipped++
+			continue
+		}
+		available = append(available, user)
+	}
+
+	return available, skipped, nil
+}
+
+// assignableUsers оставляет кандидатов, которых можно назначить ревьюером прямо сейчас:
+// не автора, не стажеров, не исключенных правилами автора, не отсутствующих
+// и не достигших лимита ревью. Второе значение - сколько кандидатов отброшено из-за лимита.
+func (s *Service) assignableUsers(ctx context.Context, sel *selection, users []*models.User) ([]*models.User, int, error) {
+	sel.trace.consider(users)
+
+	var candidates []*models.User
+	for _, user := range users {
+		switch rule := sel.rules.exclusion(user.UserID); {
+		case user.UserID == sel.authorID:
+			sel.trace.reject(user.UserID, "author of the pull request")
+		case user.IsTrainee:
+			sel.trace.reject(user.UserID, "trainee, assigned only as a shadow reviewer")
+		case rule != nil:
+			sel.trace.reject(user.UserID, "excluded by "+ruleReason(rule))
+		default:
+			candidates = append(candidates, user)
+		}
+	}
+
+	available, err := s.filterAvailable(ctx, candidates)
+	if err != nil {
+		return nil, 0, err
+	}
+	sel.trace.rejectMissing(candidates, available, "out of office")
+
+	withCapacity, skipped, err := s.filterByCapacity(ctx, available, sel.batch)
+	if err != nil {
+		return nil, 0, err
+	}
+	sel.trace.rejectMissing(available, withCapacity, "open review limit reached")
+
+	return withCapacity, skipped, nil
+}
+
+// resolvePendingAssignments добирает ревьюеров для PR из очереди, старые первыми.
+// Ошибки отдельных PR логируются и не прерывают обработку остальных.
+func (s *Service) resolvePendingAssignments(ctx context.Context) {
+	pending, err := s.repo.GetPendingAssignments(ctx)
+	if err != nil {
+		s.logger.Printf("Load pending assignments: %v", err)
+		return
+	}
+
+	for _, pa := range pending {
+		if err := s.fillPendingAssignment(ctx, pa); err != nil {
+			s.logger.Printf("Resolve pending assignment for PR %s: %v", pa.PullRequestID, err)
+		}
+	}
+}
+
+func (s *Service) fillPendingAssignment(ctx context.Context, pa *models.PendingAssignment) error {
+	pr, err := s.repo.GetPullRequest(ctx, pa.PullRequestID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.MarkPendingAssignmentAttempt(ctx, pr.PullRequestID); err != nil {
+		return err
+	}
+
+	if len(pr.AssignedReviewers) >= pa.DesiredCount {
+		return s.repo.DeletePendingAssignment(ctx, pr.PullRequestID)
+	}
+
+	teamName, err := s.reviewerTeam(ctx, pr)
+	if err != nil {
+		return err
+	}
+
+	teamUsers, err := s.repo.GetActiveUsersByTeam(ctx, teamName)
+	if err != nil {
+		return err
+	}
+
+	sel, err := s.newSelection(ctx, pr.AuthorID, pr.Files, s.randFor(pr.PullRequestID))
+	if err != nil {
+		return err
+	}
+	sel.trace = newAssignmentTrace()
+
+	candidates, _, err := s.assignableUsers(ctx, sel, teamUsers)
+	if err != nil {
+		return err
+	}
+
+	reviewerIDs, err := s.pickReviewers(ctx, sel, teamName, candidates, pr.AssignedReviewers, pa.DesiredCount)
+	if err != nil {
+		return err
+	}
+	added := reviewerIDs[len(pr.AssignedReviewers):]
+
+	// PR, созданный без ревьюеров, получает стажера вместе с первым ревьюером
+	var shadow *models.ShadowReviewer
+	if len(pr.ShadowReviewers) == 0 {
+		shadow, err = s.pickShadowReviewer(ctx, sel, teamUsers, reviewerIDs)
+		if err != nil {
+			return err
+		}
+	}
+	if len(added) == 0 && shadow == nil {
+		return nil
+	}
+
+	fill := &models.PendingAssignmentFill{
+		PullRequestID: pr.PullRequestID,
+		Reviewers:     pr.AssignedReviewers,
+		Added:         added,
+		Shadow:        shadow,
+		Advance:       sel.advance,
+		Done:          len(reviewerIDs) >= pa.DesiredCount,
+	}
+	if len(added) > 0 {
+		filled := *pr
+		filled.AssignedReviewers = reviewerIDs
+		if shadow != nil {
+			filled.ShadowReviewers = []models.ShadowReviewer{*shadow}
+		}
+		fill.Event = pullRequestEvent(models.EventReviewersAssigned, &filled, added, "")
+	}
+
+	// Запись проверяет, что PR не изменился с момента загрузки: иначе его уже
+	// добрала другая реплика, смержили или переназначили
+	err = s.repo.FillPendingAssignment(ctx, fill)
+	if errors.Is(err, repository.ErrPendingAssignmentStale) || errors.Is(err, repository.ErrRoundRobinMoved) {
+		s.info.Printf("Pending PR %s changed while assigning reviewers, will retry", pr.PullRequestID)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(added) > 0 {
+		s.info.Printf("Assigned %v to pending PR %s", added, pr.PullRequestID)
+		pr.AssignedReviewers = reviewerIDs
+		s.events.publish()
+	}
+	if shadow != nil {
+		pr.ShadowReviewers = []models.ShadowReviewer{*shadow}
+	}
+
+	if len(added) > 0 {
+		for _, id := range added {
+			sel.trace.selected(id, "selected by "+sel.strategy+" strategy")
+		}
+		s.saveExplanation(ctx, &models.AssignmentExplanation{
+			PullRequestID:     pr.PullRequestID,
+			Event:             models.AssignmentEventPending,
+			AuthorID:          pr.AuthorID,
+			TeamName:          teamName,
+			Repository:        pr.Repository,
+			Strategy:          sel.strategy,
+			ReviewerCount:     pa.DesiredCount,
+			AssignedReviewers: added,
+			Candidates:        sel.trace.result(),
+		})
+	}
+
+	return nil
+}
+
+// reviewerTeam - команда, из которой назначаются ревьюеры PR:
+// команда-владелец репозитория, иначе команда автора
+func (s *Service) reviewerTeam(ctx context.Context, pr *models.PullRequest) (string, error) {
+	if pr.Repository != "" {
+		repo, err := s.repo.GetRepository(ctx, pr.Repository)
+		if err != nil {
+			return "", err
+		}
+		if repo.TeamName != "" {
+			return repo.TeamName, nil
+		}
+	}
+
+	author, err := s.repo.GetUser(ctx, pr.AuthorID)
+	if err != nil {
+		return "", err
+	}
+
+	return author.TeamName, nil
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"prmanager/internal/codeowners"
+	"prmanager/internal/models"
+	"sort"
+	"strings"
+)
+
+// Codeowners
+func (s *Service) SetCodeowners(ctx context.Context, repository, content string) (*models.Codeowners, error) {
+	s.info.Printf("Uploading CODEOWNERS for repository: %s", repository)
+
+	exists, err := s.repo.RepositoryExists(ctx, repository)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.New("REPOSITORY_NOT_FOUND")
+	}
+
+	// Проверяем что файл разбирается
+	if _, err := codeowners.Parse(strings.NewReader(content)); err != nil {
+		s.logger.Printf("Invalid CODEOWNERS for %s: %v", repository, err)
+		return nil, errors.New("INVALID_CODEOWNERS")
+	}
+
+	if err := s.repo.SetCodeowners(ctx, repository, content); err != nil {
+		return nil, err
+	}
+
+	return &models.Codeowners{Repository: repository, Content: content}, nil
+}
+
+func (s *Service) GetCodeowners(ctx context.Context, repository string) (*models.Codeowners, error) {
+	s.info.Printf("Getting CODEOWNERS for repository: %s", repository)
+
+	content, err := s.repo.GetCodeowners(ctx, repository)
+	if err != nil {
+		return nil, errors.New("NOT_FOUND")
+	}
+
+	return &models.Codeowners{Repository: repository, Content: content}, nil
+}
+
+// assignCodeowners подбирает минимальный набор владельцев так, чтобы у каждого
+// измененного пути с владельцами был назначен хотя бы один из них.
+func (s *Service) assignCodeowners(ctx context.Context, sel *selection, repository string) []string {
+	if repository == "" || len(sel.files) == 0 {
+		return nil
+	}
+
+	content, err := s.repo.GetCodeowners(ctx, repository)
+	if err != nil {
+		return nil
+	}
+
+	rules, err := codeowners.Parse(strings.NewReader(content))
+	if err != nil {
+		s.logger.Printf("Stored CODEOWNERS for %s is invalid: %v", repository, err)
+		return nil
+	}
+
+	// Для каждого файла - активные владельцы, которых можно назначить
+	resolved := make(map[string][]*models.User)
+	pending := make(map[string][]*models.User)
+	for _, file := range sel.files {
+		var owners []*models.User
+		for _, token := range rules.Owners(file) {
+			if _, ok := resolved[token]; !ok {
+				resolved[token] = s.resolveOwner(ctx, sel, token)
+			}
+			owners = append(owners, resolved[token]...)
+		}
+
+		if len(owners) > 0 {
+			pending[file] = owners
+		} else if len(rules.Owners(file)) > 0 {
+			s.info.Printf("No available owner for %s in %s", file, repository)
+		}
+	}
+
+	var reviewerIDs []string
+	for len(pending) > 0 {
+		// Выбираем владельца, покрывающего больше всего оставшихся файлов
+		coverage := make(map[string]int)
+		var users []string
+		for _, owners := range pending {
+			seen := make(map[string]bool)
+			for _, owner := range owners {
+				if seen[owner.UserID] {
+					continue
+				}
+				seen[owner.UserID] = true
+				if coverage[owner.UserID] == 0 {
+					users = append(users, owner.UserID)
+				}
+				coverage[owner.UserID]++
+			}
+		}
+
+		// Порядок обхода map случаен - сортируем, чтобы выбор зависел только от rng
+		sort.Strings(users)
+		sel.rng.Shuffle(len(users), func(i, j int) {
+			users[i], users[j] = users[j], users[i]
+		})
+		best := users[0]
+		for _, userID := range users[1:] {
+			if coverage[userID] > coverage[best] {
+				best = userID
+			}
+		}
+
+		reviewerIDs = append(reviewerIDs, best)
+		sel.trace.note(best, fmt.Sprintf("code owner of %d changed files", coverage[best]))
+		for file, owners := range pending {
+			for _, owner := range owners {
+				if owner.UserID == best {
+					delete(pending, file)
+					break
+				}
+			}
+		}
+	}
+
+	return reviewerIDs
+}
+
+// resolveOwner превращает владельца из CODEOWNERS в пользователей:
+// сначала ищется пользователь с таким id, затем команда ("org/team" -> "team").
+func (s *Service) resolveOwner(ctx context.Context, sel *selection, token string) []*models.User {
+	var users []*models.User
+
+	if user, err := s.repo.GetUser(ctx, token); err == nil {
+		users = []*models.User{user}
+	} else {
+		teamName := token[strings.LastIndex(token, "/")+1:]
+		users, err = s.repo.GetActiveUsersByTeam(ctx, teamName)
+		if err != nil {
+			return nil
+		}
+	}
+
+	var active []*models.User
+	for _, user := range users {
+		if user.IsActive {
+			active = append(active, user)
+		}
+	}
+
+	available, _, err := s.assignableUsers(ctx, sel, active)
+	if err != nil {
+		s.logger.Printf("Check availability of owner %s: %v", token, err)
+		return nil
+	}
+
+	return available
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// encodeCursor превращает ключ последнего элемента страницы в непрозрачную строку
+func encodeCursor(cursor any) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(value string, cursor any) error {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, cursor)
+}
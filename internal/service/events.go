@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"prmanager/internal/models"
+	"sync"
+)
+
+const (
+	// defaultEventsLimit - сколько событий отдается за одно чтение потока
+	defaultEventsLimit = 100
+	maxEventsLimit     = 1000
+)
+
+// eventBroker будит подписчиков потока событий после записи нового события.
+// Сами события читаются из БД, сигнал только сокращает задержку.
+type eventBroker struct {
+	mu          sync.Mutex
+	subscribers map[chan struct{}]struct{}
+}
+
+func newEventBroker() *eventBroker {
+	return &eventBroker{subscribers: make(map[chan struct{}]struct{})}
+}
+
+func (b *eventBroker) subscribe() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		delete(b.subscribers, ch)
+		b.mu.Unlock()
+	}
+}
+
+// publish будит всех подписчиков; повторные сигналы до чтения схлопываются
+func (b *eventBroker) publish() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// SubscribePullRequestEvents возвращает канал сигналов о новых событиях
+// этого экземпляра сервиса и функцию отписки. События других реплик
+// сигнала не дают - их подхватывает периодическое чтение.
+func (s *Service) SubscribePullRequestEvents() (<-chan struct{}, func()) {
+	return s.events.subscribe()
+}
+
+// GetPullRequestEvents - события после filter.AfterID, старые первыми
+func (s *Service) GetPullRequestEvents(ctx context.Context, filter models.EventFilter) ([]*models.PullRequestEvent, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultEventsLimit
+	}
+	if filter.Limit > maxEventsLimit {
+		filter.Limit = maxEventsLimit
+	}
+
+	return s.repo.GetPullRequestEvents(ctx, filter)
+}
+
+// LastPullRequestEventID - позиция конца потока для новых подписчиков
+func (s *Service) LastPullRequestEventID(ctx context.Context) (int64, error) {
+	return s.repo.LastPullRequestEventID(ctx)
+}
+
+// pullRequestEvent строит событие PR по его состоянию после изменения.
+// Событие записывается репозиторием в транзакции самого изменения, поэтому
+// не теряется и не появляется без него.
+func pullRequestEvent(eventType string, pr *models.PullRequest, added []string, oldReviewerID string) *models.PullRequestEvent {
+	event := &models.PullRequestEvent{
+		Type:              eventType,
+		PullRequestID:     pr.PullRequestID,
+		AuthorID:          pr.AuthorID,
+		Repository:        pr.Repository,
+		Status:            pr.Status,
+		AssignedReviewers: pr.AssignedReviewers,
+		AddedReviewers:    added,
+		OldReviewerID:     oldReviewerID,
+	}
+	if eventType == models.EventReviewerReassigned && len(added) == 1 {
+		event.NewReviewerID = added[0]
+	}
+	if event.AssignedReviewers == nil {
+		event.AssignedReviewers = []string{}
+	}
+
+	// Участники события: автор, ревьюеры и стажеры, а также снятый ревьюер
+	event.UserIDs = append([]string{pr.AuthorID}, pr.AssignedReviewers...)
+	for _, shadow := range pr.ShadowReviewers {
+		event.UserIDs = append(event.UserIDs, shadow.UserID)
+	}
+	if oldReviewerID != "" {
+		event.UserIDs = append(event.UserIDs, oldReviewerID)
+	}
+
+	return event
+}
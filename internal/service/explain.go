@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"prmanager/internal/models"
+)
+
+// assignmentPlan - ревьюеры, подобранные для нового PR, и объяснение выбора
+type assignmentPlan struct {
+	reviewerIDs []string
+	shadow      *models.ShadowReviewer
+	explanation *models.AssignmentExplanation
+	// advance - сдвиг курсора round_robin, записывается вместе с PR
+	advance *models.RoundRobinAdvance
+}
+
+// planAssignment прогоняет подбор ревьюеров нового PR: фильтры и правила,
+// владельцы CODEOWNERS, стратегия команды и стажер. Ничего не пишет: сдвиг курсора
+// round_robin возвращается в плане. batch - еще не записанные назначения пакета
+// импорта, nil вне импорта.
+func (s *Service) planAssignment(ctx context.Context, pr *models.PullRequest, repo *models.Repository, reviewerCount int, batch *assignmentBatch) (*assignmentPlan, error) {
+	// Проверяем существует ли автор
+	author, err := s.repo.GetUser(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, errors.New("AUTHOR_NOT_FOUND")
+	}
+
+	// Ревьюеры берутся из команды-владельца репозитория, иначе из команды автора
+	teamName := author.TeamName
+	if repo != nil && repo.TeamName != "" {
+		teamName = repo.TeamName
+	}
+
+	// Получаем активных пользователей команды для назначения ревьюеров
+	teamUsers, err := s.repo.GetActiveUsersByTeam(ctx, teamName)
+	if err != nil {
+		return nil, errors.New("TEAM_NOT_FOUND")
+	}
+
+	sel, err := s.newSelection(ctx, pr.AuthorID, pr.Files, s.randFor(pr.PullRequestID))
+	if err != nil {
+		return nil, err
+	}
+	sel.trace = newAssignmentTrace()
+	sel.batch = batch
+
+	// Отсутствующие по расписанию, перегруженные, стажеры и исключенные правилами не назначаются
+	candidates, _, err := s.assignableUsers(ctx, sel, teamUsers)
+	if err != nil {
+		return nil, err
+	}
+
+	// Сначала владельцы измененных путей, затем случайные ревьюеры на оставшиеся места
+	ownerIDs := s.assignCodeowners(ctx, sel, pr.Repository)
+	reviewerIDs, err := s.pickReviewers(ctx, sel, teamName, candidates, ownerIDs, reviewerCount)
+	if err != nil {
+		return nil, err
+	}
+
+	// Стажер наблюдает за ревью в паре с одним из ревьюеров
+	shadow, err := s.pickShadowReviewer(ctx, sel, teamUsers, reviewerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := make(map[string]bool, len(ownerIDs))
+	for _, id := range ownerIDs {
+		owners[id] = true
+	}
+	for _, id := range reviewerIDs {
+		if owners[id] {
+			sel.trace.selected(id, "selected as code owner")
+		} else {
+			sel.trace.selected(id, "selected by "+sel.strategy+" strategy")
+		}
+	}
+
+	explanation := &models.AssignmentExplanation{
+		AuthorID:          pr.AuthorID,
+		TeamName:          teamName,
+		Repository:        pr.Repository,
+		Strategy:          sel.strategy,
+		ReviewerCount:     reviewerCount,
+		AssignedReviewers: reviewerIDs,
+		Candidates:        sel.trace.result(),
+	}
+	if shadow != nil {
+		explanation.ShadowReviewers = []models.ShadowReviewer{*shadow}
+	}
+
+	return &assignmentPlan{
+		reviewerIDs: reviewerIDs,
+		shadow:      shadow,
+		explanation: explanation,
+		advance:     sel.advance,
+	}, nil
+}
+
+// PreviewAssignment показывает, кого бы назначили на PR с такими автором,
+// репозиторием и файлами, и почему. Ничего не записывает.
+func (s *Service) PreviewAssignment(ctx context.Context, pr *models.PullRequest) (*models.AssignmentExplanation, error) {
+	s.info.Printf("Previewing assignment for author: %s", pr.AuthorID)
+
+	reviewerCount := s.reviewerCount
+	var repo *models.Repository
+	if pr.Repository != "" {
+		var err error
+		repo, err = s.repo.GetRepository(ctx, pr.Repository)
+		if err != nil {
+			return nil, errors.New("REPOSITORY_NOT_FOUND")
+		}
+		reviewerCount = repo.DefaultReviewerCount
+	}
+
+	plan, err := s.planAssignment(ctx, pr, repo, reviewerCount, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.explanation.PullRequestID = pr.PullRequestID
+	return plan.explanation, nil
+}
+
+// GetAssignmentExplanations - история подбора ревьюеров PR: создание, добор, переназначения
+func (s *Service) GetAssignmentExplanations(ctx context.Context, prID string) ([]*models.AssignmentExplanation, error) {
+	s.info.Printf("Getting assignment explanations for PR: %s", prID)
+
+	exists, err := s.repo.PRExists(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.New("NOT_FOUND")
+	}
+
+	return s.repo.GetAssignmentExplanations(ctx, prID)
+}
+
+// saveExplanation сохраняет объяснение; ошибка не отменяет уже сделанное назначение
+func (s *Service) saveExplanation(ctx context.Context, explanation *models.AssignmentExplanation) {
+	if err := s.repo.SaveAssignmentExplanation(ctx, explanation); err != nil {
+		s.logger.Printf("Save assignment explanation for PR %s: %v", explanation.PullRequestID, err)
+	}
+}
@@ -0,0 +1,357 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"prmanager/internal/models"
+	"prmanager/internal/repository"
+	"strings"
+	"time"
+)
+
+const (
+	// maxImportItems - сколько PR принимается за один импорт
+	maxImportItems = 10000
+	// defaultImportChunkSize - размер транзакции в режиме chunked
+	defaultImportChunkSize = 100
+	maxImportChunkSize     = 1000
+)
+
+// importError - причина, по которой PR не импортирован; Error() - код для отчета
+type importError struct {
+	code    string
+	message string
+}
+
+func (e *importError) Error() string {
+	return e.code
+}
+
+// Message - причина для клиента; обработчики берут ее вместо общего текста кода
+func (e *importError) Message() string {
+	return e.message
+}
+
+func rejectImport(code, message string) error {
+	return &importError{code: code, message: message}
+}
+
+// importedPullRequest - PR, прошедший проверку и готовый к записи
+type importedPullRequest struct {
+	result *models.PullRequestImportResult
+	pr     *models.PullRequest
+	// pendingCount - желаемое число ревьюеров, если PR ставится в очередь на добор
+	pendingCount int
+	explanation  *models.AssignmentExplanation
+	// advance - сдвиг курсора round_robin, записывается вместе с PR
+	advance *models.RoundRobinAdvance
+}
+
+// ImportPullRequests создает существующие PR пакетом. В режиме atomic все PR
+// пишутся одной транзакцией и только если все прошли проверку; в режиме chunked -
+// транзакциями по chunkSize PR, некорректные PR пропускаются.
+// Ревьюеры без assigned_reviewers подбираются как при создании PR; лимиты ревью
+// учитывают и еще не записанные PR той же транзакции, а курсор round_robin
+// сдвигается только вместе с записью PR.
+func (s *Service) ImportPullRequests(ctx context.Context, items []*models.PullRequestImportItem, mode string, chunkSize int) (*models.PullRequestImportReport, error) {
+	s.info.Printf("Importing %d pull requests (mode %q)", len(items), mode)
+
+	if mode == "" {
+		mode = models.ImportModeAtomic
+	}
+	if mode != models.ImportModeAtomic && mode != models.ImportModeChunked {
+		return nil, rejectImport("INVALID_REQUEST", "mode must be atomic or chunked")
+	}
+	if len(items) == 0 {
+		return nil, rejectImport("INVALID_REQUEST", "at least one pull request is required")
+	}
+	if len(items) > maxImportItems {
+		return nil, rejectImport("INVALID_REQUEST", fmt.Sprintf("at most %d pull requests per import", maxImportItems))
+	}
+	if chunkSize < 0 || chunkSize > maxImportChunkSize {
+		return nil, rejectImport("INVALID_REQUEST", fmt.Sprintf("chunk_size must be between 1 and %d", maxImportChunkSize))
+	}
+	if chunkSize == 0 {
+		chunkSize = defaultImportChunkSize
+	}
+	if mode == models.ImportModeAtomic {
+		chunkSize = len(items)
+	}
+
+	report := &models.PullRequestImportReport{
+		Mode:    mode,
+		Total:   len(items),
+		Results: make([]*models.PullRequestImportResult, 0, len(items)),
+	}
+	// seen - id и пары (репозиторий, номер) PR из уже разобранной части импорта
+	seen := make(map[string]bool)
+
+	for start := 0; start < len(items); start += chunkSize {
+		end := min(start+chunkSize, len(items))
+
+		var (
+			ready  []*importedPullRequest
+			failed bool
+		)
+		batch := newAssignmentBatch()
+		for i := start; i < end; i++ {
+			result := &models.PullRequestImportResult{Index: i, PullRequestID: items[i].PullRequestID}
+			report.Results = append(report.Results, result)
+
+			imported, err := s.prepareImport(ctx, items[i], seen, batch)
+			if err != nil {
+				s.failImport(result, err)
+				failed = true
+				continue
+			}
+			imported.result = result
+			result.PullRequestID = imported.pr.PullRequestID
+			ready = append(ready, imported)
+		}
+
+		if failed && mode == models.ImportModeAtomic {
+			for _, imported := range ready {
+				imported.result.Status = models.ImportItemSkipped
+				imported.result.Message = "not imported because other pull requests in the batch failed"
+			}
+			continue
+		}
+
+		s.writeImport(ctx, ready)
+	}
+
+	for _, result := range report.Results {
+		switch result.Status {
+		case models.ImportItemCreated:
+			report.Created++
+		case models.ImportItemFailed:
+			report.Failed++
+		case models.ImportItemSkipped:
+			report.Skipped++
+		}
+	}
+
+	return report, nil
+}
+
+// prepareImport проверяет PR из импорта и подбирает ревьюеров, ничего не записывая.
+// Назначения открытого PR добавляются в batch, чтобы следующие PR транзакции их учитывали.
+func (s *Service) prepareImport(ctx context.Context, item *models.PullRequestImportItem, seen map[string]bool, batch *assignmentBatch) (*importedPullRequest, error) {
+	pr := &models.PullRequest{
+		PullRequestID:   item.PullRequestID,
+		PullRequestName: item.PullRequestName,
+		AuthorID:        item.AuthorID,
+		Repository:      item.Repository,
+		Number:          item.Number,
+		Files:           item.Files,
+		Status:          strings.ToUpper(item.Status),
+	}
+	if pr.Status == "" {
+		pr.Status = "OPEN"
+	}
+	if pr.Status != "OPEN" && pr.Status != "MERGED" {
+		return nil, rejectImport("INVALID_REQUEST", "status must be OPEN or MERGED")
+	}
+	if pr.PullRequestName == "" {
+		return nil, rejectImport("INVALID_REQUEST", "pull_request_name is required")
+	}
+	if pr.Number > math.MaxInt32 {
+		return nil, rejectImport("INVALID_REQUEST", "number is out of range")
+	}
+
+	reviewerCount := s.reviewerCount
+	var repo *models.Repository
+
+	if pr.Repository != "" {
+		var err error
+		repo, err = s.repo.GetRepository(ctx, pr.Repository)
+		if err != nil {
+			return nil, rejectImport("REPOSITORY_NOT_FOUND", "repository not found")
+		}
+		reviewerCount = repo.DefaultReviewerCount
+
+		if pr.Number > 0 {
+			if pr.PullRequestID == "" {
+				pr.PullRequestID = fmt.Sprintf("%s#%d", pr.Repository, pr.Number)
+			}
+
+			numberKey := fmt.Sprintf("%s\x00%d", pr.Repository, pr.Number)
+			if seen[numberKey] {
+				return nil, rejectImport("DUPLICATE", "repository and number appear more than once in the import")
+			}
+			seen[numberKey] = true
+
+			exists, err := s.repo.PRNumberExists(ctx, pr.Repository, pr.Number)
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				return nil, rejectImport("PR_EXISTS", "pull request with this repository and number already exists")
+			}
+		}
+	}
+
+	if pr.PullRequestID == "" || len(pr.PullRequestID) > maxPullRequestIDLength {
+		return nil, rejectImport("INVALID_REQUEST", "pull_request_id or repository and number are required")
+	}
+	if seen[pr.PullRequestID] {
+		return nil, rejectImport("DUPLICATE", "pull_request_id appears more than once in the import")
+	}
+	seen[pr.PullRequestID] = true
+
+	exists, err := s.repo.PRExists(ctx, pr.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, rejectImport("PR_EXISTS", "PR id already exists")
+	}
+
+	if _, err := s.repo.GetUser(ctx, pr.AuthorID); err != nil {
+		return nil, rejectImport("AUTHOR_NOT_FOUND", "author not found")
+	}
+
+	// Время создания по умолчанию - сейчас, время мержа - время создания
+	pr.CreatedAt = time.Now()
+	if item.CreatedAt != nil {
+		pr.CreatedAt = *item.CreatedAt
+	}
+	if pr.Status == "MERGED" {
+		mergedAt := pr.CreatedAt
+		if item.MergedAt != nil {
+			mergedAt = *item.MergedAt
+		}
+		if mergedAt.Before(pr.CreatedAt) {
+			return nil, rejectImport("INVALID_REQUEST", "mergedAt must not be before createdAt")
+		}
+		pr.MergedAt = &mergedAt
+	} else if item.MergedAt != nil {
+		return nil, rejectImport("INVALID_REQUEST", "mergedAt is only allowed for MERGED pull requests")
+	}
+
+	imported := &importedPullRequest{pr: pr}
+
+	switch {
+	case item.AssignedReviewers != nil:
+		// Заданные ревьюеры переносятся как есть: активность и лимиты на момент импорта не важны
+		assigned := make(map[string]bool)
+		for _, reviewerID := range item.AssignedReviewers {
+			if reviewerID == pr.AuthorID {
+				return nil, rejectImport("INVALID_REQUEST", "author cannot review own pull request")
+			}
+			if assigned[reviewerID] {
+				return nil, rejectImport("INVALID_REQUEST", "reviewer "+reviewerID+" is listed twice")
+			}
+			assigned[reviewerID] = true
+
+			if _, err := s.repo.GetUser(ctx, reviewerID); err != nil {
+				return nil, rejectImport("REVIEWER_NOT_FOUND", "reviewer "+reviewerID+" not found")
+			}
+		}
+		pr.AssignedReviewers = item.AssignedReviewers
+
+	case pr.Status == "OPEN":
+		plan, err := s.planAssignment(ctx, pr, repo, reviewerCount, batch)
+		if err != nil {
+			switch err.Error() {
+			case "AUTHOR_NOT_FOUND":
+				return nil, rejectImport("AUTHOR_NOT_FOUND", "author not found")
+			case "TEAM_NOT_FOUND":
+				return nil, rejectImport("TEAM_NOT_FOUND", "team not found")
+			}
+			return nil, err
+		}
+		pr.AssignedReviewers = plan.reviewerIDs
+		if plan.shadow != nil {
+			pr.ShadowReviewers = []models.ShadowReviewer{*plan.shadow}
+		}
+		if len(plan.reviewerIDs) < reviewerCount {
+			pr.PendingAssignment = true
+			imported.pendingCount = reviewerCount
+		}
+		plan.explanation.PullRequestID = pr.PullRequestID
+		plan.explanation.Event = models.AssignmentEventCreate
+		imported.explanation = plan.explanation
+		imported.advance = plan.advance
+
+	default:
+		pr.AssignedReviewers = []string{}
+	}
+
+	if pr.Status == "OPEN" {
+		batch.add(pr.AssignedReviewers, imported.advance)
+	}
+
+	return imported, nil
+}
+
+// writeImport записывает проверенные PR одной транзакцией
+func (s *Service) writeImport(ctx context.Context, ready []*importedPullRequest) {
+	if len(ready) == 0 {
+		return
+	}
+
+	prs := make([]*models.PullRequest, 0, len(ready))
+	pendingCounts := make(map[string]int)
+	var advances []*models.RoundRobinAdvance
+	events := make([]*models.PullRequestEvent, 0, len(ready))
+	for _, imported := range ready {
+		prs = append(prs, imported.pr)
+		events = append(events, pullRequestEvent(models.EventPullRequestCreated, imported.pr, imported.pr.AssignedReviewers, ""))
+		if imported.advance != nil {
+			advances = append(advances, imported.advance)
+		}
+		if imported.pendingCount > 0 {
+			pendingCounts[imported.pr.PullRequestID] = imported.pendingCount
+		}
+	}
+
+	if err := s.repo.ImportPullRequests(ctx, prs, pendingCounts, advances, events); err != nil {
+		code, message := "INTERNAL_ERROR", "transaction failed, no pull requests of this chunk were written"
+		if errors.Is(err, repository.ErrRoundRobinMoved) {
+			// Параллельное назначение сдвинуло курсор: подбор пакета устарел
+			code, message = "ROUND_ROBIN_MOVED", "round robin order changed by a concurrent assignment, no pull requests of this chunk were written; retry the import"
+		} else {
+			s.logger.Printf("Import %d pull requests: %v", len(prs), err)
+		}
+		for _, imported := range ready {
+			imported.result.Status = models.ImportItemFailed
+			imported.result.Error = code
+			imported.result.Message = message
+		}
+		return
+	}
+
+	for _, imported := range ready {
+		imported.result.Status = models.ImportItemCreated
+		imported.result.AssignedReviewers = imported.pr.AssignedReviewers
+		imported.result.PendingAssignment = imported.pr.PendingAssignment
+
+		if imported.explanation != nil {
+			s.saveExplanation(ctx, imported.explanation)
+		}
+	}
+	s.events.publish()
+
+	if len(pendingCounts) > 0 {
+		s.notifyPendingAssignments()
+	}
+}
+
+// failImport записывает в отчет причину отказа; внутренние ошибки не раскрываются
+func (s *Service) failImport(result *models.PullRequestImportResult, err error) {
+	result.Status = models.ImportItemFailed
+
+	var rejected *importError
+	if errors.As(err, &rejected) {
+		result.Error = rejected.code
+		result.Message = rejected.message
+		return
+	}
+
+	s.logger.Printf("Import pull request %d: %v", result.Index, err)
+	result.Error = "INTERNAL_ERROR"
+	result.Message = "Internal server error"
+}
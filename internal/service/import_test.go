@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"prmanager/internal/models"
+	"testing"
+)
+
+func TestImportPullRequestsRejected(t *testing.T) {
+	items := func(n int) []*models.PullRequestImportItem {
+		return make([]*models.PullRequestImportItem, n)
+	}
+
+	tests := []struct {
+		name        string
+		items       []*models.PullRequestImportItem
+		mode        string
+		chunkSize   int
+		wantMessage string
+	}{
+		{"unknown mode", items(1), "partial", 0, "mode must be atomic or chunked"},
+		{"empty list", nil, models.ImportModeAtomic, 0, "at least one pull request is required"},
+		{"too many", items(maxImportItems + 1), models.ImportModeAtomic, 0, "at most 10000 pull requests per import"},
+		{"chunk too large", items(1), models.ImportModeChunked, maxImportChunkSize + 1, "chunk_size must be between 1 and 1000"},
+		{"negative chunk", items(1), models.ImportModeChunked, -1, "chunk_size must be between 1 and 1000"},
+	}
+
+	// Запрос проверяется до обращения к базе
+	s := newTestService()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.ImportPullRequests(context.Background(), tt.items, tt.mode, tt.chunkSize)
+
+			var rejected *importError
+			if !errors.As(err, &rejected) || rejected.Error() != "INVALID_REQUEST" || rejected.Message() != tt.wantMessage {
+				t.Errorf("err = %v (%+v), want INVALID_REQUEST %q", err, rejected, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"prmanager/internal/models"
+	"strings"
+)
+
+const (
+	defaultPullRequestPageSize = 50
+	maxPullRequestPageSize     = 200
+)
+
+func (s *Service) GetPullRequest(ctx context.Context, prID string) (*models.PullRequest, error) {
+	s.info.Printf("Getting PR: %s", prID)
+
+	pr, err := s.repo.GetPullRequest(ctx, prID)
+	if err != nil {
+		return nil, errors.New("NOT_FOUND")
+	}
+
+	return pr, nil
+}
+
+// ListPullRequests возвращает страницу PR по фильтру. cursor - значение next_cursor
+// предыдущей страницы; курсор другой сортировки или другого запроса недействителен.
+func (s *Service) ListPullRequests(ctx context.Context, filter models.PullRequestFilter, cursor string) (*models.PullRequestPage, error) {
+	s.info.Printf("Listing PRs, query: %q", filter.Query)
+
+	filter.Query = strings.TrimSpace(filter.Query)
+	if filter.SortBy == "" {
+		filter.SortBy = models.PullRequestSortCreated
+		if filter.Query != "" {
+			filter.SortBy = models.PullRequestSortRelevance
+		}
+	}
+
+	switch filter.SortBy {
+	case models.PullRequestSortCreated, models.PullRequestSortMerged:
+	case models.PullRequestSortRelevance:
+		if filter.Query == "" {
+			return nil, errors.New("INVALID_REQUEST")
+		}
+	default:
+		return nil, errors.New("INVALID_REQUEST")
+	}
+	if filter.Status != "" && filter.Status != "OPEN" && filter.Status != "MERGED" {
+		return nil, errors.New("INVALID_REQUEST")
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultPullRequestPageSize
+	}
+	if filter.Limit < 0 || filter.Limit > maxPullRequestPageSize {
+		return nil, errors.New("INVALID_REQUEST")
+	}
+
+	if cursor != "" {
+		after, err := parsePullRequestCursor(cursor, filter)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = after
+	}
+
+	// Лишняя строка показывает, есть ли следующая страница
+	limit := filter.Limit
+	filter.Limit++
+	prs, err := s.repo.ListPullRequests(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.PullRequestPage{PullRequests: prs}
+	if len(prs) > limit {
+		page.PullRequests = prs[:limit]
+
+		page.NextCursor = nextPullRequestCursor(filter, page.PullRequests[limit-1])
+	}
+	if page.PullRequests == nil {
+		page.PullRequests = []*models.PullRequestListItem{}
+	}
+
+	return page, nil
+}
+
+// nextPullRequestCursor - курсор страницы, следующей за last
+func nextPullRequestCursor(filter models.PullRequestFilter, last *models.PullRequestListItem) string {
+	next := &models.PullRequestCursor{
+		SortBy:    filter.SortBy,
+		Ascending: filter.Ascending,
+		Query:     filter.Query,
+		ID:        last.PullRequestID,
+	}
+	switch filter.SortBy {
+	case models.PullRequestSortRelevance:
+		next.Rank = last.Rank
+	case models.PullRequestSortMerged:
+		next.Time = last.MergedAt
+	default:
+		next.Time = &last.CreatedAt
+	}
+	return encodeCursor(next)
+}
+
+// parsePullRequestCursor разбирает курсор и проверяет, что он выдан для той же
+// сортировки и того же поискового запроса
+func parsePullRequestCursor(cursor string, filter models.PullRequestFilter) (*models.PullRequestCursor, error) {
+	var after models.PullRequestCursor
+	if err := decodeCursor(cursor, &after); err != nil || after.ID == "" ||
+		after.SortBy != filter.SortBy || after.Ascending != filter.Ascending || after.Query != filter.Query ||
+		(filter.SortBy == models.PullRequestSortRelevance) != (after.Rank != nil) ||
+		(filter.SortBy != models.PullRequestSortRelevance) != (after.Time != nil) {
+		return nil, errors.New("INVALID_CURSOR")
+	}
+	return &after, nil
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"prmanager/internal/models"
+	"testing"
+	"time"
+)
+
+func TestPullRequestCursorRoundTrip(t *testing.T) {
+	mergedAt := time.Date(2025, 3, 12, 18, 15, 0, 555, time.FixedZone("MSK", 3*60*60))
+	rank, zeroRank := 0.0759909, 0.0
+	item := func(rank *float64) *models.PullRequestListItem {
+		return &models.PullRequestListItem{
+			PullRequest: models.PullRequest{
+				PullRequestID: "backend#42",
+				CreatedAt:     time.Date(2025, 3, 10, 9, 0, 0, 123456789, time.UTC),
+				MergedAt:      &mergedAt,
+			},
+			Rank: rank,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		filter   models.PullRequestFilter
+		last     *models.PullRequestListItem
+		wantTime *time.Time
+		wantRank *float64
+	}{
+		{
+			name:     "created",
+			filter:   models.PullRequestFilter{SortBy: models.PullRequestSortCreated},
+			last:     item(nil),
+			wantTime: &item(nil).CreatedAt,
+		},
+		{
+			name:     "merged ascending",
+			filter:   models.PullRequestFilter{SortBy: models.PullRequestSortMerged, Ascending: true, Status: "MERGED"},
+			last:     item(nil),
+			wantTime: &mergedAt,
+		},
+		{
+			name:     "relevance",
+			filter:   models.PullRequestFilter{SortBy: models.PullRequestSortRelevance, Query: "fix login"},
+			last:     item(&rank),
+			wantRank: &rank,
+		},
+		{
+			name:     "relevance with zero rank",
+			filter:   models.PullRequestFilter{SortBy: models.PullRequestSortRelevance, Query: "fix"},
+			last:     item(&zeroRank),
+			wantRank: &zeroRank,
+		},
+		{
+			name:     "created with query",
+			filter:   models.PullRequestFilter{SortBy: models.PullRequestSortCreated, Query: "fix"},
+			last:     item(&rank),
+			wantTime: &item(nil).CreatedAt,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			after, err := parsePullRequestCursor(nextPullRequestCursor(tt.filter, tt.last), tt.filter)
+			if err != nil {
+				t.Fatalf("parse own cursor: %v", err)
+			}
+
+			if after.ID != tt.last.PullRequestID || after.SortBy != tt.filter.SortBy ||
+				after.Ascending != tt.filter.Ascending || after.Query != tt.filter.Query {
+				t.Errorf("cursor = %+v, want key of %s for %+v", after, tt.last.PullRequestID, tt.filter)
+			}
+			if (after.Time == nil) != (tt.wantTime == nil) || (after.Time != nil && !after.Time.Equal(*tt.wantTime)) {
+				t.Errorf("cursor time = %v, want %v", after.Time, tt.wantTime)
+			}
+			if (after.Rank == nil) != (tt.wantRank == nil) || (after.Rank != nil && *after.Rank != *tt.wantRank) {
+				t.Errorf("cursor rank = %v, want %v", after.Rank, tt.wantRank)
+			}
+		})
+	}
+}
+
+func TestPullRequestCursorRejected(t *testing.T) {
+	rank := 0.5
+	last := &models.PullRequestListItem{
+		PullRequest: models.PullRequest{PullRequestID: "pr-1", CreatedAt: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)},
+		Rank:        &rank,
+	}
+	byCreated := nextPullRequestCursor(models.PullRequestFilter{SortBy: models.PullRequestSortCreated}, last)
+	byRelevance := nextPullRequestCursor(models.PullRequestFilter{SortBy: models.PullRequestSortRelevance, Query: "fix"}, last)
+	createdAt := last.CreatedAt
+
+	tests := []struct {
+		name   string
+		cursor string
+		filter models.PullRequestFilter
+	}{
+		{"other sort", byCreated, models.PullRequestFilter{SortBy: models.PullRequestSortMerged}},
+		{"other direction", byCreated, models.PullRequestFilter{SortBy: models.PullRequestSortCreated, Ascending: true}},
+		{"query added", byCreated, models.PullRequestFilter{SortBy: models.PullRequestSortCreated, Query: "fix"}},
+		{"other query", byRelevance, models.PullRequestFilter{SortBy: models.PullRequestSortRelevance, Query: "login"}},
+		{"relevance without rank", encodeCursor(models.PullRequestCursor{SortBy: models.PullRequestSortRelevance, Query: "fix", Time: &createdAt, ID: "pr-1"}),
+			models.PullRequestFilter{SortBy: models.PullRequestSortRelevance, Query: "fix"}},
+		{"time sort without time", encodeCursor(models.PullRequestCursor{SortBy: models.PullRequestSortMerged, Rank: &rank, ID: "pr-1"}),
+			models.PullRequestFilter{SortBy: models.PullRequestSortMerged}},
+		{"no id", encodeCursor(models.PullRequestCursor{SortBy: models.PullRequestSortCreated, Time: &createdAt}),
+			models.PullRequestFilter{SortBy: models.PullRequestSortCreated}},
+		{"not base64", "%%%", models.PullRequestFilter{SortBy: models.PullRequestSortCreated}},
+	}
+
+	s := newTestService()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parsePullRequestCursor(tt.cursor, tt.filter); err == nil || err.Error() != "INVALID_CURSOR" {
+				t.Errorf("parsePullRequestCursor err = %v, want INVALID_CURSOR", err)
+			}
+
+			// Курсор проверяется до обращения к базе
+			if _, err := s.ListPullRequests(context.Background(), tt.filter, tt.cursor); err == nil || err.Error() != "INVALID_CURSOR" {
+				t.Errorf("ListPullRequests err = %v, want INVALID_CURSOR", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Option настраивает Service при создании
+type Option func(*Service)
+
+// WithRandSource задает источник случайности для назначения ревьюеров.
+// Источник с фиксированным seed делает назначения воспроизводимыми (тесты, replay).
+func WithRandSource(src rand.Source) Option {
+	return func(s *Service) {
+		s.rng = rand.New(&lockedSource{src: src})
+	}
+}
+
+// WithDeterministicAssignment включает выбор, зависящий только от id PR (и seed):
+// повторная попытка создать тот же PR дает тех же ревьюеров.
+func WithDeterministicAssignment(seed int64) Option {
+	return func(s *Service) {
+		s.deterministic = true
+		s.seed = seed
+	}
+}
+
+// randFor возвращает генератор для операции над PR. В детерминированном режиме
+// он заново инициализируется хешем ключей, иначе используется общий генератор.
+func (s *Service) randFor(keys ...string) *rand.Rand {
+	if !s.deterministic {
+		return s.rng
+	}
+
+	h := fnv.New64a()
+	for _, key := range keys {
+		h.Write([]byte(key))
+		h.Write([]byte{0})
+	}
+	return rand.New(rand.NewSource(int64(h.Sum64()) ^ s.seed))
+}
+
+func defaultRand() *rand.Rand {
+	return rand.New(&lockedSource{src: rand.NewSource(time.Now().UnixNano())})
+}
+
+// lockedSource - потокобезопасная обертка над rand.Source:
+// Service обслуживает конкурентные запросы общим генератором
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source
+}
+
+func (l *lockedSource) Int63() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.src.Int63()
+}
+
+func (l *lockedSource) Seed(seed int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.src.Seed(seed)
+}
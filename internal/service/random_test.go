@@ -0,0 +1,111 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"prmanager/internal/models"
+	"prmanager/internal/repository"
+	"reflect"
+	"testing"
+)
+
+func newTestService(opts ...Option) *Service {
+	return NewService(repository.Repository{}, log.New(io.Discard, "", 0), opts...)
+}
+
+func testTeam(size int) []*models.User {
+	users := make([]*models.User, 0, size)
+	for i := 0; i < size; i++ {
+		users = append(users, &models.User{
+			UserID:       fmt.Sprintf("u%d", i),
+			IsActive:     true,
+			ReviewWeight: 1,
+		})
+	}
+	return users
+}
+
+// pickForPR выбирает ревьюеров так же, как создание PR, но без обращения к БД
+func pickForPR(s *Service, prID string, team []*models.User, count int) []string {
+	sel := &selection{
+		rng:      s.randFor(prID),
+		authorID: team[0].UserID,
+		rules:    &authorRules{},
+	}
+	return s.autoAssignReviewers(sel, team, nil, count)
+}
+
+func TestDeterministicAssignmentReplay(t *testing.T) {
+	team := testTeam(10)
+
+	first := newTestService(WithDeterministicAssignment(42))
+	second := newTestService(WithDeterministicAssignment(42))
+
+	for i := 0; i < 20; i++ {
+		prID := fmt.Sprintf("pr-%d", i)
+
+		want := pickForPR(first, prID, team, 2)
+		if got := pickForPR(second, prID, team, 2); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: service with the same seed picked %v, want %v", prID, got, want)
+		}
+		// Повторная попытка тем же сервисом не зависит от предыдущих вызовов
+		if got := pickForPR(first, prID, team, 2); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: repeated pick %v, want %v", prID, got, want)
+		}
+	}
+}
+
+func TestDeterministicAssignmentVaries(t *testing.T) {
+	team := testTeam(10)
+
+	base := newTestService(WithDeterministicAssignment(42))
+	otherSeed := newTestService(WithDeterministicAssignment(43))
+
+	seedDiffers, prDiffers := false, false
+	reference := pickForPR(base, "pr-0", team, 2)
+	for i := 0; i < 20; i++ {
+		prID := fmt.Sprintf("pr-%d", i)
+		picked := pickForPR(base, prID, team, 2)
+
+		if !reflect.DeepEqual(pickForPR(otherSeed, prID, team, 2), picked) {
+			seedDiffers = true
+		}
+		if !reflect.DeepEqual(picked, reference) {
+			prDiffers = true
+		}
+	}
+
+	if !seedDiffers {
+		t.Error("a different seed picked the same reviewers for all 20 pull requests")
+	}
+	if !prDiffers {
+		t.Error("all 20 pull requests got the same reviewers")
+	}
+}
+
+func TestRandSourceReplay(t *testing.T) {
+	team := testTeam(10)
+
+	first := newTestService(WithRandSource(rand.NewSource(7)))
+	second := newTestService(WithRandSource(rand.NewSource(7)))
+	other := newTestService(WithRandSource(rand.NewSource(8)))
+
+	differs := false
+	for i := 0; i < 20; i++ {
+		prID := fmt.Sprintf("pr-%d", i)
+
+		want := pickForPR(first, prID, team, 3)
+		if got := pickForPR(second, prID, team, 3); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: replay picked %v, want %v", prID, got, want)
+		}
+		if !reflect.DeepEqual(pickForPR(other, prID, team, 3), want) {
+			differs = true
+		}
+	}
+
+	if !differs {
+		t.Error("a different seed replayed the same 20 assignments")
+	}
+}
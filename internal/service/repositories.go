@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"prmanager/internal/models"
+	"strconv"
+)
+
+// maxRepositoryNameLength - с таким именем id "repository#number" помещается
+// в pull_requests.id при любом number из колонки INTEGER
+var maxRepositoryNameLength = maxPullRequestIDLength - len("#"+strconv.Itoa(math.MaxInt32))
+
+// Repositories
+func (s *Service) CreateRepository(ctx context.Context, repo *models.Repository) (*models.Repository, error) {
+	s.info.Printf("Creating repository: %s", repo.Name)
+
+	if repo.DefaultReviewerCount < 0 || len(repo.Name) > maxRepositoryNameLength {
+		return nil, errors.New("INVALID_REQUEST")
+	}
+
+	exists, err := s.repo.RepositoryExists(ctx, repo.Name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.New("REPOSITORY_EXISTS")
+	}
+
+	if err := s.checkTeamExists(ctx, repo.TeamName); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateRepository(ctx, repo); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetRepository(ctx, repo.Name)
+}
+
+func (s *Service) GetRepository(ctx context.Context, name string) (*models.Repository, error) {
+	s.info.Printf("Getting repository: %s", name)
+
+	repo, err := s.repo.GetRepository(ctx, name)
+	if err != nil {
+		return nil, errors.New("NOT_FOUND")
+	}
+
+	return repo, nil
+}
+
+func (s *Service) ListRepositories(ctx context.Context) ([]*models.Repository, error) {
+	s.info.Println("Listing repositories")
+
+	return s.repo.ListRepositories(ctx)
+}
+
+// UpdateRepository меняет только переданные (не nil) поля. Пустое имя команды снимает владельца.
+func (s *Service) UpdateRepository(ctx context.Context, name string, reviewerCount *int, teamName *string) (*models.Repository, error) {
+	s.info.Printf("Updating repository: %s", name)
+
+	repo, err := s.repo.GetRepository(ctx, name)
+	if err != nil {
+		return nil, errors.New("NOT_FOUND")
+	}
+
+	if reviewerCount != nil {
+		if *reviewerCount < 0 {
+			return nil, errors.New("INVALID_REQUEST")
+		}
+		repo.DefaultReviewerCount = *reviewerCount
+	}
+	if teamName != nil {
+		if err := s.checkTeamExists(ctx, *teamName); err != nil {
+			return nil, err
+		}
+		repo.TeamName = *teamName
+	}
+
+	if err := s.repo.UpdateRepository(ctx, repo); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetRepository(ctx, name)
+}
+
+func (s *Service) DeleteRepository(ctx context.Context, name string) error {
+	s.info.Printf("Deleting repository: %s", name)
+
+	exists, err := s.repo.RepositoryExists(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.New("NOT_FOUND")
+	}
+
+	// PR репозитория удалять нельзя - они хранят историю ревью
+	inUse, err := s.repo.RepositoryHasPullRequests(ctx, name)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return errors.New("REPOSITORY_IN_USE")
+	}
+
+	return s.repo.DeleteRepository(ctx, name)
+}
+
+func (s *Service) checkTeamExists(ctx context.Context, teamName string) error {
+	if teamName == "" {
+		return nil
+	}
+
+	exists, err := s.repo.TeamExists(ctx, teamName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.New("TEAM_NOT_FOUND")
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"prmanager/internal/models"
+)
+
+const (
+	defaultReviewPageSize = 50
+	maxReviewPageSize     = 200
+)
+
+// GetUserReviews возвращает страницу PR, назначенных пользователю. cursor - значение
+// next_cursor предыдущей страницы; курсор другой сортировки считается недействительным.
+func (s *Service) GetUserReviews(ctx context.Context, filter models.ReviewFilter, cursor string) (*models.ReviewPage, error) {
+	s.info.Printf("Getting reviews for user: %s", filter.ReviewerID)
+
+	if filter.SortBy == "" {
+		filter.SortBy = models.ReviewSortCreated
+	}
+	if filter.SortBy != models.ReviewSortCreated && filter.SortBy != models.ReviewSortAssigned {
+		return nil, errors.New("INVALID_REQUEST")
+	}
+	if filter.Status != "" && filter.Status != "OPEN" && filter.Status != "MERGED" {
+		return nil, errors.New("INVALID_REQUEST")
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultReviewPageSize
+	}
+	if filter.Limit < 0 || filter.Limit > maxReviewPageSize {
+		return nil, errors.New("INVALID_REQUEST")
+	}
+
+	if cursor != "" {
+		after, err := parseReviewCursor(cursor, filter)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = after
+	}
+
+	// Проверяем существует ли пользователь
+	if _, err := s.repo.GetUser(ctx, filter.ReviewerID); err != nil {
+		return nil, errors.New("NOT_FOUND")
+	}
+
+	// Лишняя строка показывает, есть ли следующая страница
+	limit := filter.Limit
+	filter.Limit++
+	prs, err := s.repo.GetPullRequestsByReviewer(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.ReviewPage{PullRequests: prs}
+	if len(prs) > limit {
+		page.PullRequests = prs[:limit]
+
+		page.NextCursor = nextReviewCursor(filter, page.PullRequests[limit-1])
+	}
+	if page.PullRequests == nil {
+		page.PullRequests = []*models.UserReview{}
+	}
+
+	return page, nil
+}
+
+// nextReviewCursor - курсор страницы, следующей за last
+func nextReviewCursor(filter models.ReviewFilter, last *models.UserReview) string {
+	next := &models.ReviewCursor{SortBy: filter.SortBy, Ascending: filter.Ascending, Time: last.CreatedAt, ID: last.PullRequestID}
+	if filter.SortBy == models.ReviewSortAssigned {
+		next.Time = last.AssignedAt
+	}
+	return encodeCursor(next)
+}
+
+// parseReviewCursor разбирает курсор и проверяет, что он выдан для той же сортировки
+func parseReviewCursor(cursor string, filter models.ReviewFilter) (*models.ReviewCursor, error) {
+	var after models.ReviewCursor
+	if err := decodeCursor(cursor, &after); err != nil || after.ID == "" || after.Time.IsZero() ||
+		after.SortBy != filter.SortBy || after.Ascending != filter.Ascending {
+		return nil, errors.New("INVALID_CURSOR")
+	}
+	return &after, nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"prmanager/internal/models"
+	"testing"
+	"time"
+)
+
+func TestReviewCursorRoundTrip(t *testing.T) {
+	zone := time.FixedZone("MSK", 3*60*60)
+	last := &models.UserReview{
+		PullRequestShort: models.PullRequestShort{PullRequestID: "backend#42"},
+		CreatedAt:        time.Date(2025, 3, 10, 9, 0, 0, 123456789, zone),
+		AssignedAt:       time.Date(2025, 3, 11, 14, 30, 0, 987654321, time.UTC),
+	}
+
+	tests := []struct {
+		sortBy    string
+		ascending bool
+		wantTime  time.Time
+	}{
+		{models.ReviewSortCreated, false, last.CreatedAt},
+		{models.ReviewSortCreated, true, last.CreatedAt},
+		{models.ReviewSortAssigned, false, last.AssignedAt},
+		{models.ReviewSortAssigned, true, last.AssignedAt},
+	}
+
+	for _, tt := range tests {
+		filter := models.ReviewFilter{ReviewerID: "u1", SortBy: tt.sortBy, Ascending: tt.ascending}
+
+		after, err := parseReviewCursor(nextReviewCursor(filter, last), filter)
+		if err != nil {
+			t.Fatalf("%s ascending=%t: parse own cursor: %v", tt.sortBy, tt.ascending, err)
+		}
+		if after.ID != last.PullRequestID || !after.Time.Equal(tt.wantTime) ||
+			after.SortBy != tt.sortBy || after.Ascending != tt.ascending {
+			t.Errorf("%s ascending=%t: cursor = %+v, want id %s time %v", tt.sortBy, tt.ascending, after, last.PullRequestID, tt.wantTime)
+		}
+	}
+}
+
+func TestReviewCursorRejected(t *testing.T) {
+	issued := models.ReviewFilter{ReviewerID: "u1", SortBy: models.ReviewSortCreated}
+	cursor := nextReviewCursor(issued, &models.UserReview{
+		PullRequestShort: models.PullRequestShort{PullRequestID: "pr-1"},
+		CreatedAt:        time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC),
+	})
+
+	tests := []struct {
+		name   string
+		cursor string
+		filter models.ReviewFilter
+	}{
+		{"other sort", cursor, models.ReviewFilter{ReviewerID: "u1", SortBy: models.ReviewSortAssigned}},
+		{"other direction", cursor, models.ReviewFilter{ReviewerID: "u1", SortBy: models.ReviewSortCreated, Ascending: true}},
+		{"not base64", "%%%", issued},
+		{"not json", base64.RawURLEncoding.EncodeToString([]byte("{")), issued},
+		{"no id", encodeCursor(models.ReviewCursor{SortBy: models.ReviewSortCreated, Time: time.Now()}), issued},
+		{"no time", encodeCursor(models.ReviewCursor{SortBy: models.ReviewSortCreated, ID: "pr-1"}), issued},
+	}
+
+	s := newTestService()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseReviewCursor(tt.cursor, tt.filter); err == nil || err.Error() != "INVALID_CURSOR" {
+				t.Errorf("parseReviewCursor err = %v, want INVALID_CURSOR", err)
+			}
+
+			// Курсор проверяется до обращения к базе
+			if _, err := s.GetUserReviews(context.Background(), tt.filter, tt.cursor); err == nil || err.Error() != "INVALID_CURSOR" {
+				t.Errorf("GetUserReviews err = %v, want INVALID_CURSOR", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"prmanager/internal/models"
+)
+
+// Assignment rules
+func (s *Service) CreateAssignmentRule(ctx context.Context, rule *models.AssignmentRule) (*models.AssignmentRule, error) {
+	s.info.Printf("Creating %s rule: author %s, reviewer %s", rule.Kind, rule.AuthorID, rule.ReviewerID)
+
+	if rule.Kind != models.RuleExclude && rule.Kind != models.RulePrefer {
+		return nil, errors.New("INVALID_RULE")
+	}
+	if rule.AuthorID == rule.ReviewerID {
+		return nil, errors.New("INVALID_RULE")
+	}
+
+	for _, userID := range []string{rule.AuthorID, rule.ReviewerID} {
+		if _, err := s.repo.GetUser(ctx, userID); err != nil {
+			return nil, errors.New("NOT_FOUND")
+		}
+	}
+
+	exists, err := s.repo.AssignmentRuleExists(ctx, rule.Kind, rule.AuthorID, rule.ReviewerID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.New("RULE_EXISTS")
+	}
+
+	if err := s.repo.CreateAssignmentRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *Service) DeleteAssignmentRule(ctx context.Context, id string) error {
+	s.info.Printf("Deleting assignment rule: %s", id)
+
+	deleted, err := s.repo.DeleteAssignmentRule(ctx, id)
+	if err != nil || !deleted {
+		return errors.New("NOT_FOUND")
+	}
+
+	// Снятое исключение могло открыть кандидата для PR из очереди
+	s.notifyPendingAssignments()
+
+	return nil
+}
+
+func (s *Service) ListAssignmentRules(ctx context.Context, authorID string) ([]*models.AssignmentRule, error) {
+	s.info.Printf("Listing assignment rules, author: %q", authorID)
+
+	return s.repo.GetAssignmentRules(ctx, authorID)
+}
+
+// DryRunAssignment показывает, кто из команды автора прошел бы фильтры и правила
+// и кого назначили бы на его PR с файлами files. Ничего не записывает.
+func (s *Service) DryRunAssignment(ctx context.Context, authorID string, files []string) (*models.AssignmentExplanation, error) {
+	explanation, err := s.PreviewAssignment(ctx, &models.PullRequest{AuthorID: authorID, Files: files})
+	if err != nil && err.Error() == "AUTHOR_NOT_FOUND" {
+		return nil, errors.New("NOT_FOUND")
+	}
+	return explanation, err
+}
+
+// authorRules - правила автора PR, разложенные по ревьюерам
+type authorRules struct {
+	excluded  map[string]*models.AssignmentRule
+	preferred map[string]*models.AssignmentRule
+}
+
+func (s *Service) loadAuthorRules(ctx context.Context, authorID string) (*authorRules, error) {
+	rules, err := s.repo.GetAssignmentRules(ctx, authorID)
+	if err != nil {
+		return nil, fmt.Errorf("load assignment rules: %w", err)
+	}
+
+	ar := &authorRules{
+		excluded:  make(map[string]*models.AssignmentRule),
+		preferred: make(map[string]*models.AssignmentRule),
+	}
+	for _, rule := range rules {
+		switch rule.Kind {
+		case models.RuleExclude:
+			ar.excluded[rule.ReviewerID] = rule
+		case models.RulePrefer:
+			ar.preferred[rule.ReviewerID] = rule
+		}
+	}
+
+	return ar, nil
+}
+
+// isPreferred - есть ли PREFER-правило для ревьюера (исключение сильнее предпочтения)
+func (ar *authorRules) isPreferred(userID string) bool {
+	if ar == nil {
+		return false
+	}
+	_, excluded := ar.excluded[userID]
+	_, preferred := ar.preferred[userID]
+	return preferred && !excluded
+}
+
+func (ar *authorRules) exclusion(userID string) *models.AssignmentRule {
+	if ar == nil {
+		return nil
+	}
+	return ar.excluded[userID]
+}
+
+func ruleReason(rule *models.AssignmentRule) string {
+	if rule.Reason == "" {
+		return fmt.Sprintf("%s rule %s", rule.Kind, rule.ID)
+	}
+	return fmt.Sprintf("%s rule %s: %s", rule.Kind, rule.ID, rule.Reason)
+}
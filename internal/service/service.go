@@ -3,29 +3,85 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
+	"math"
 	"math/rand"
 	"prmanager/internal/models"
 	"prmanager/internal/repository"
 	"time"
 )
 
+const (
+	// defaultReviewerCount - сколько ревьюеров назначается на PR вне репозитория,
+	// если не задано WithDefaultReviewerCount
+	defaultReviewerCount = 2
+	// maxPullRequestIDLength - длина колонки pull_requests.id
+	maxPullRequestIDLength = 50
+	// maxRoundRobinAttempts - сколько раз повторяется подбор, если курсор round_robin
+	// сдвинулся между выбором и записью PR
+	maxRoundRobinAttempts = 5
+)
+
 // Service - реализация сервиса
 type Service struct {
 	repo   repository.Repository
 	logger *log.Logger
+	// info - ход операций; logger - только ошибки (см. WithInfoLogger)
+	info *log.Logger
+	// pendingSignal будит воркер очереди назначений
+	pendingSignal chan struct{}
+	// events будит подписчиков потока событий PR
+	events *eventBroker
+	// rng - общий генератор для выбора ревьюеров (см. WithRandSource)
+	rng *rand.Rand
+	// deterministic - выбор ревьюеров зависит только от id PR и seed
+	deterministic bool
+	seed          int64
+	// reviewerCount - сколько ревьюеров назначается на PR вне репозитория
+	reviewerCount int
+}
+
+func NewService(repo repository.Repository, logger *log.Logger, opts ...Option) *Service {
+	s := &Service{
+		repo:          repo,
+		logger:        logger,
+		info:          logger,
+		pendingSignal: make(chan struct{}, 1),
+		events:        newEventBroker(),
+		rng:           defaultRand(),
+		reviewerCount: defaultReviewerCount,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
-func NewService(repo repository.Repository, logger *log.Logger) *Service {
-	return &Service{
-		repo:   repo,
-		logger: logger,
+// WithInfoLogger задает отдельный логгер для хода операций (по умолчанию - общий);
+// log.New(io.Discard, "", 0) оставляет в логе только ошибки
+func WithInfoLogger(logger *log.Logger) Option {
+	return func(s *Service) {
+		s.info = logger
+	}
+}
+
+// WithDefaultReviewerCount задает число ревьюеров PR вне репозитория
+func WithDefaultReviewerCount(count int) Option {
+	return func(s *Service) {
+		s.reviewerCount = count
 	}
 }
 
 // Teams
 func (s *Service) CreateTeam(ctx context.Context, team *models.Team) (*models.Team, error) {
-	s.logger.Printf("Creating team: %s", team.TeamName)
+	s.info.Printf("Creating team: %s", team.TeamName)
+
+	if !validStrategy(team.AssignmentStrategy) {
+		return nil, errors.New("INVALID_STRATEGY")
+	}
 
 	// Проверяем существует ли команда
 	exists, err := s.repo.TeamExists(ctx, team.TeamName)
@@ -42,11 +98,14 @@ func (s *Service) CreateTeam(ctx context.Context, team *models.Team) (*models.Te
 		return nil, err
 	}
 
+	// Новые участники могут закрыть нехватку ревьюеров
+	s.notifyPendingAssignments()
+
 	return team, nil
 }
 
 func (s *Service) GetTeam(ctx context.Context, teamName string) (*models.Team, error) {
-	s.logger.Printf("Getting team: %s", teamName)
+	s.info.Printf("Getting team: %s", teamName)
 
 	team, err := s.repo.GetTeam(ctx, teamName)
 	if err != nil {
@@ -56,91 +115,215 @@ func (s *Service) GetTeam(ctx context.Context, teamName string) (*models.Team, e
 	return team, nil
 }
 
+func (s *Service) SetTeamStrategy(ctx context.Context, teamName, strategy string) (*models.Team, error) {
+	s.info.Printf("Setting assignment strategy for team %s: %s", teamName, strategy)
+
+	if strategy == "" || !validStrategy(strategy) {
+		return nil, errors.New("INVALID_STRATEGY")
+	}
+
+	exists, err := s.repo.TeamExists(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.New("NOT_FOUND")
+	}
+
+	if err := s.repo.SetTeamStrategy(ctx, teamName, strategy); err != nil {
+		return nil, err
+	}
+
+	team, err := s.repo.GetTeam(ctx, teamName)
+	if err != nil {
+		return nil, errors.New("NOT_FOUND")
+	}
+
+	return team, nil
+}
+
+// validStrategy - пустая стратегия означает стратегию по умолчанию
+func validStrategy(strategy string) bool {
+	switch strategy {
+	case "", models.StrategyRandom, models.StrategyRoundRobin:
+		return true
+	}
+	return false
+}
+
 // Users
 func (s *Service) SetUserActive(ctx context.Context, userID string, isActive bool) (*models.User, error) {
-	s.logger.Printf("Setting user %s active: %t", userID, isActive)
+	s.info.Printf("Setting user %s active: %t", userID, isActive)
 
 	user, err := s.repo.UpdateUserActive(ctx, userID, isActive)
 	if err != nil {
 		return nil, errors.New("NOT_FOUND")
 	}
 
+	if isActive {
+		s.notifyPendingAssignments()
+	}
+
 	return user, nil
 }
 
-func (s *Service) GetUserReviews(ctx context.Context, userID string) ([]*models.PullRequestShort, error) {
-	s.logger.Printf("Getting reviews for user: %s", userID)
+func (s *Service) SetUserExpertise(ctx context.Context, userID string, expertise []string) (*models.User, error) {
+	s.info.Printf("Setting expertise for user %s: %v", userID, expertise)
 
-	// Проверяем существует ли пользователь
-	_, err := s.repo.GetUser(ctx, userID)
-	if err != nil {
+	if _, err := s.repo.GetUser(ctx, userID); err != nil {
 		return nil, errors.New("NOT_FOUND")
 	}
 
-	prs, err := s.repo.GetPullRequestsByReviewer(ctx, userID)
+	user, err := s.repo.SetUserExpertise(ctx, userID, expertise)
 	if err != nil {
 		return nil, err
 	}
 
-	return prs, nil
+	return user, nil
 }
 
-// Pull Requests
-func (s *Service) CreatePullRequest(ctx context.Context, prID, title, authorID string) (*models.PullRequest, error) {
-	s.logger.Printf("Creating PR: %s, author: %s", prID, authorID)
+func (s *Service) SetUserReviewWeight(ctx context.Context, userID string, weight float64) (*models.User, error) {
+	s.info.Printf("Setting review weight for user %s: %g", userID, weight)
 
-	// Проверяем существует ли PR
-	exists, err := s.repo.PRExists(ctx, prID)
+	if weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+		return nil, errors.New("INVALID_REQUEST")
+	}
+
+	user, err := s.repo.SetUserReviewWeight(ctx, userID, weight)
 	if err != nil {
-		return nil, err
+		return nil, errors.New("NOT_FOUND")
 	}
-	if exists {
-		return nil, errors.New("PR_EXISTS")
+
+	return user, nil
+}
+
+// UpdateUser применяет частичное изменение пользователя целиком: сначала
+// проверяются все поля, затем они записываются одной транзакцией
+func (s *Service) UpdateUser(ctx context.Context, userID string, update models.UserUpdate) (*models.User, error) {
+	s.info.Printf("Updating user %s", userID)
+
+	if update.Empty() {
+		return nil, errors.New("INVALID_REQUEST")
+	}
+	if update.MaxOpenReviews != nil && *update.MaxOpenReviews < 0 {
+		return nil, errors.New("INVALID_REQUEST")
+	}
+	if weight := update.ReviewWeight; weight != nil && (*weight < 0 || math.IsNaN(*weight) || math.IsInf(*weight, 0)) {
+		return nil, errors.New("INVALID_REQUEST")
 	}
 
-	// Проверяем существует ли автор
-	author, err := s.repo.GetUser(ctx, authorID)
-	if err != nil {
-		return nil, errors.New("AUTHOR_NOT_FOUND")
+	if _, err := s.repo.GetUser(ctx, userID); err != nil {
+		return nil, errors.New("NOT_FOUND")
 	}
 
-	// Получаем активных пользователей команды для назначения ревьюеров
-	teamUsers, err := s.repo.GetActiveUsersByTeam(ctx, author.TeamName)
+	user, err := s.repo.ApplyUserUpdate(ctx, userID, update)
 	if err != nil {
-		return nil, errors.New("TEAM_NOT_FOUND")
+		return nil, err
 	}
 
-	// Автоназначение ревьюеров
-	reviewerIDs := s.autoAssignReviewers(authorID, teamUsers)
+	// Активация и новый лимит могли освободить место для PR из очереди
+	if (update.IsActive != nil && *update.IsActive) || update.SetMaxOpenReviews {
+		s.notifyPendingAssignments()
+	}
 
-	pr := &models.PullRequest{
-		PullRequestID:     prID,
-		PullRequestName:   title,
-		AuthorID:          authorID,
-		Status:            "OPEN",
-		AssignedReviewers: reviewerIDs,
-		CreatedAt:         time.Now(),
+	return user, nil
+}
+
+// Pull Requests
+func (s *Service) CreatePullRequest(ctx context.Context, pr *models.PullRequest) (*models.PullRequest, error) {
+	s.info.Printf("Creating PR: %s, author: %s", pr.PullRequestID, pr.AuthorID)
+
+	if pr.Number > math.MaxInt32 {
+		return nil, errors.New("INVALID_REQUEST")
+	}
+
+	reviewerCount := s.reviewerCount
+	var repo *models.Repository
+
+	// PR внутри репозитория адресуется парой (repository, number)
+	if pr.Repository != "" {
+		var err error
+		repo, err = s.repo.GetRepository(ctx, pr.Repository)
+		if err != nil {
+			return nil, errors.New("REPOSITORY_NOT_FOUND")
+		}
+		reviewerCount = repo.DefaultReviewerCount
+
+		if pr.Number > 0 {
+			if pr.PullRequestID == "" {
+				pr.PullRequestID = fmt.Sprintf("%s#%d", pr.Repository, pr.Number)
+			}
+
+			exists, err := s.repo.PRNumberExists(ctx, pr.Repository, pr.Number)
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				return nil, errors.New("PR_EXISTS")
+			}
+		}
+	}
+
+	if pr.PullRequestID == "" || len(pr.PullRequestID) > maxPullRequestIDLength {
+		return nil, errors.New("INVALID_REQUEST")
 	}
 
-	// Создаем PR
-	err = s.repo.CreatePullRequest(ctx, pr)
+	// Проверяем существует ли PR
+	exists, err := s.repo.PRExists(ctx, pr.PullRequestID)
 	if err != nil {
 		return nil, err
 	}
+	if exists {
+		return nil, errors.New("PR_EXISTS")
+	}
+
+	pr.Status = "OPEN"
+	pr.CreatedAt = time.Now()
+
+	// Курсор round_robin сдвигается в транзакции создания PR; если его успело сдвинуть
+	// конкурентное назначение, подбор повторяется с новой позиции
+	var plan *assignmentPlan
+	for attempt := 1; ; attempt++ {
+		plan, err = s.planAssignment(ctx, pr, repo, reviewerCount, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		pr.AssignedReviewers = plan.reviewerIDs
+		pr.ShadowReviewers = nil
+		if plan.shadow != nil {
+			pr.ShadowReviewers = []models.ShadowReviewer{*plan.shadow}
+		}
 
-	// Назначаем ревьюеров (если есть)
-	if len(reviewerIDs) > 0 {
-		err = s.repo.AssignReviewers(ctx, prID, reviewerIDs)
+		// Ревьюеров не хватило - PR ждет в очереди, пока кто-нибудь освободится
+		pr.PendingAssignment = len(plan.reviewerIDs) < reviewerCount
+		pendingCount := 0
+		if pr.PendingAssignment {
+			pendingCount = reviewerCount
+		}
+
+		event := pullRequestEvent(models.EventPullRequestCreated, pr, pr.AssignedReviewers, "")
+		err = s.repo.CreatePullRequest(ctx, pr, pendingCount, plan.advance, event)
+		if errors.Is(err, repository.ErrRoundRobinMoved) && attempt < maxRoundRobinAttempts {
+			s.info.Printf("Round robin cursor moved while creating PR %s, retrying", pr.PullRequestID)
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
+		break
 	}
 
+	plan.explanation.PullRequestID = pr.PullRequestID
+	plan.explanation.Event = models.AssignmentEventCreate
+	s.saveExplanation(ctx, plan.explanation)
+	s.events.publish()
+
 	return pr, nil
 }
 
 func (s *Service) MergePullRequest(ctx context.Context, prID string) (*models.PullRequest, error) {
-	s.logger.Printf("Merging PR: %s", prID)
+	s.info.Printf("Merging PR: %s", prID)
 
 	// Получаем PR
 	pr, err := s.repo.GetPullRequest(ctx, prID)
@@ -153,18 +336,29 @@ func (s *Service) MergePullRequest(ctx context.Context, prID string) (*models.Pu
 		return pr, nil
 	}
 
-	// Обновляем статус
+	// Обновляем статус; событие пишется в той же транзакции
 	mergedAt := time.Now()
-	updatedPR, err := s.repo.UpdatePullRequestStatus(ctx, prID, "MERGED", &mergedAt)
+	merged := *pr
+	merged.Status = "MERGED"
+	event := pullRequestEvent(models.EventPullRequestMerged, &merged, nil, "")
+	updatedPR, err := s.repo.UpdatePullRequestStatus(ctx, prID, "MERGED", &mergedAt, event)
 	if err != nil {
 		return nil, err
 	}
 
+	// Ревьюеры смерженного PR освободились - пробуем разобрать очередь
+	if err := s.repo.DeletePendingAssignment(ctx, prID); err != nil {
+		s.logger.Printf("Remove merged PR %s from pending queue: %v", prID, err)
+	}
+	updatedPR.PendingAssignment = false
+	s.notifyPendingAssignments()
+	s.events.publish()
+
 	return updatedPR, nil
 }
 
 func (s *Service) ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (*models.ReassignResult, error) {
-	s.logger.Printf("Reassigning reviewer %s in PR: %s", oldReviewerID, prID)
+	s.info.Printf("Reassigning reviewer %s in PR: %s", oldReviewerID, prID)
 
 	// Получаем PR
 	pr, err := s.repo.GetPullRequest(ctx, prID)
@@ -201,23 +395,55 @@ func (s *Service) ReassignReviewer(ctx context.Context, prID, oldReviewerID stri
 		return nil, errors.New("TEAM_NOT_FOUND")
 	}
 
+	sel, err := s.newSelection(ctx, pr.AuthorID, pr.Files, s.randFor(prID, oldReviewerID))
+	if err != nil {
+		return nil, err
+	}
+	sel.trace = newAssignmentTrace()
+
+	candidates, _, err = s.assignableUsers(ctx, sel, candidates)
+	if err != nil {
+		return nil, err
+	}
+
 	// Выбираем нового ревьюера
-	newReviewerID, err := s.selectNewReviewer(pr, oldReviewerID, candidates)
+	newReviewerID, err := s.selectNewReviewer(sel, pr, oldReviewerID, candidates)
 	if err != nil {
 		return nil, errors.New("NO_CANDIDATE")
 	}
 
-	// Выполняем переназначение
-	err = s.repo.ReassignReviewer(ctx, prID, oldReviewerID, newReviewerID)
+	// Выполняем переназначение; событие описывает PR уже с новым ревьюером
+	reassigned := *pr
+	reassigned.AssignedReviewers = make([]string, len(pr.AssignedReviewers))
+	for i, reviewerID := range pr.AssignedReviewers {
+		if reviewerID == oldReviewerID {
+			reviewerID = newReviewerID
+		}
+		reassigned.AssignedReviewers[i] = reviewerID
+	}
+	event := pullRequestEvent(models.EventReviewerReassigned, &reassigned, []string{newReviewerID}, oldReviewerID)
+	err = s.repo.ReassignReviewer(ctx, prID, oldReviewerID, newReviewerID, event)
 	if err != nil {
 		return nil, err
 	}
 
+	sel.trace.selected(newReviewerID, "replaces "+oldReviewerID)
+	s.saveExplanation(ctx, &models.AssignmentExplanation{
+		PullRequestID:     prID,
+		Event:             models.AssignmentEventReassign,
+		AuthorID:          pr.AuthorID,
+		TeamName:          oldReviewer.TeamName,
+		Repository:        pr.Repository,
+		AssignedReviewers: []string{newReviewerID},
+		Candidates:        sel.trace.result(),
+	})
+
 	// Получаем обновленный PR
 	updatedPR, err := s.repo.GetPullRequest(ctx, prID)
 	if err != nil {
 		return nil, err
 	}
+	s.events.publish()
 
 	return &models.ReassignResult{
 		PR:            updatedPR,
@@ -226,33 +452,42 @@ func (s *Service) ReassignReviewer(ctx context.Context, prID, oldReviewerID stri
 }
 
 // Вспомогательные методы
-func (s *Service) autoAssignReviewers(authorID string, teamUsers []*models.User) []string {
-	var candidates []string
-	for _, user := range teamUsers {
-		if user.UserID != authorID && user.IsActive {
-			candidates = append(candidates, user.UserID)
-		}
-	}
 
-	if len(candidates) == 0 {
-		return []string{}
+// autoAssignReviewers дополняет уже выбранных ревьюеров (preassigned) до count,
+// отдавая предпочтение пользователям, чья экспертиза совпадает с измененными файлами.
+// Среди равных выбор случайный с учетом веса ревьюера.
+func (s *Service) autoAssignReviewers(sel *selection, teamUsers []*models.User, preassigned []string, count int) []string {
+	reviewerIDs := append([]string{}, preassigned...)
+
+	taken := make(map[string]bool, len(preassigned))
+	for _, id := range preassigned {
+		taken[id] = true
 	}
 
-	rand.New(rand.NewSource(time.Now().UnixNano()))
-	rand.Shuffle(len(candidates), func(i, j int) {
-		candidates[i], candidates[j] = candidates[j], candidates[i]
-	})
+	var candidates []*models.User
+	for _, user := range teamUsers {
+		if user.UserID != sel.authorID && user.IsActive && !taken[user.UserID] {
+			candidates = append(candidates, user)
+		}
+	}
 
-	maxReviewers := 2
+	maxReviewers := count - len(reviewerIDs)
 	if len(candidates) < maxReviewers {
 		maxReviewers = len(candidates)
 	}
+	if maxReviewers <= 0 {
+		return reviewerIDs
+	}
+
+	for _, user := range rankCandidates(sel, candidates)[:maxReviewers] {
+		reviewerIDs = append(reviewerIDs, user.UserID)
+	}
 
-	return candidates[:maxReviewers]
+	return reviewerIDs
 }
 
-func (s *Service) selectNewReviewer(pr *models.PullRequest, oldReviewerID string, candidates []*models.User) (string, error) {
-	var availableCandidates []string
+func (s *Service) selectNewReviewer(sel *selection, pr *models.PullRequest, oldReviewerID string, candidates []*models.User) (string, error) {
+	var availableCandidates []*models.User
 
 	for _, candidate := range candidates {
 		if candidate.UserID != pr.AuthorID && candidate.UserID != oldReviewerID {
@@ -265,8 +500,12 @@ func (s *Service) selectNewReviewer(pr *models.PullRequest, oldReviewerID string
 			}
 
 			if !isCurrentReviewer {
-				availableCandidates = append(availableCandidates, candidate.UserID)
+				availableCandidates = append(availableCandidates, candidate)
+			} else {
+				sel.trace.reject(candidate.UserID, "already a reviewer of this pull request")
 			}
+		} else if candidate.UserID == oldReviewerID {
+			sel.trace.reject(candidate.UserID, "reviewer being replaced")
 		}
 	}
 
@@ -274,6 +513,5 @@ func (s *Service) selectNewReviewer(pr *models.PullRequest, oldReviewerID string
 		return "", errors.New("no available candidates")
 	}
 
-	rand.New(rand.NewSource(time.Now().UnixNano()))
-	return availableCandidates[rand.Intn(len(availableCandidates))], nil
+	return rankCandidates(sel, availableCandidates)[0].UserID, nil
 }
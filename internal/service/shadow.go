@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"prmanager/internal/models"
+)
+
+func (s *Service) SetUserTrainee(ctx context.Context, userID string, isTrainee bool) (*models.User, error) {
+	s.info.Printf("Setting user %s trainee: %t", userID, isTrainee)
+
+	user, err := s.repo.SetUserTrainee(ctx, userID, isTrainee)
+	if err != nil {
+		return nil, errors.New("NOT_FOUND")
+	}
+
+	return user, nil
+}
+
+// pickShadowReviewer выбирает стажера команды в пару к одному из назначенных ревьюеров.
+// Без обычных ревьюеров стажер не назначается: ему не у кого учиться.
+// Исключения автора (EXCLUDE) действуют и на стажеров.
+func (s *Service) pickShadowReviewer(ctx context.Context, sel *selection, teamUsers []*models.User, reviewerIDs []string) (*models.ShadowReviewer, error) {
+	if len(reviewerIDs) == 0 {
+		return nil, nil
+	}
+
+	var trainees []*models.User
+	for _, user := range teamUsers {
+		if user.IsTrainee && user.IsActive && user.UserID != sel.authorID && sel.rules.exclusion(user.UserID) == nil {
+			trainees = append(trainees, user)
+		}
+	}
+
+	trainees, err := s.filterAvailable(ctx, trainees)
+	if err != nil {
+		return nil, err
+	}
+	if len(trainees) == 0 {
+		return nil, nil
+	}
+
+	return &models.ShadowReviewer{
+		UserID:   trainees[sel.rng.Intn(len(trainees))].UserID,
+		MentorID: reviewerIDs[sel.rng.Intn(len(reviewerIDs))],
+	}, nil
+}
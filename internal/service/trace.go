@@ -0,0 +1,127 @@
+package service
+
+import (
+	"prmanager/internal/models"
+	"slices"
+	"sort"
+)
+
+// assignmentTrace записывает, почему кандидаты допущены к ревью или отброшены.
+// Методы допускают nil: без трассировки решения просто не сохраняются.
+type assignmentTrace struct {
+	order     []string
+	decisions map[string]*models.CandidateDecision
+}
+
+func newAssignmentTrace() *assignmentTrace {
+	return &assignmentTrace{decisions: make(map[string]*models.CandidateDecision)}
+}
+
+// consider регистрирует кандидатов; до первого отказа кандидат считается допущенным
+func (t *assignmentTrace) consider(users []*models.User) {
+	if t == nil {
+		return
+	}
+	for _, user := range users {
+		t.decision(user.UserID)
+	}
+}
+
+func (t *assignmentTrace) reject(userID, reason string) {
+	if t == nil {
+		return
+	}
+	d := t.decision(userID)
+	d.Accepted = false
+	d.Rank = 0
+	t.addReason(d, reason)
+}
+
+func (t *assignmentTrace) note(userID, reason string) {
+	if t == nil {
+		return
+	}
+	t.addReason(t.decision(userID), reason)
+}
+
+func (t *assignmentTrace) selected(userID, reason string) {
+	if t == nil {
+		return
+	}
+	d := t.decision(userID)
+	d.Selected = true
+	t.addReason(d, reason)
+}
+
+// rank запоминает порядок, в котором допущенные кандидаты будут выбираться
+func (t *assignmentTrace) rank(ranked []*models.User) {
+	if t == nil {
+		return
+	}
+	for i, user := range ranked {
+		if d := t.decision(user.UserID); d.Accepted {
+			d.Rank = i + 1
+		}
+	}
+}
+
+// rejectMissing отбрасывает тех из before, кого нет в after
+func (t *assignmentTrace) rejectMissing(before, after []*models.User, reason string) {
+	if t == nil {
+		return
+	}
+	kept := make(map[string]bool, len(after))
+	for _, user := range after {
+		kept[user.UserID] = true
+	}
+	for _, user := range before {
+		if !kept[user.UserID] {
+			t.reject(user.UserID, reason)
+		}
+	}
+}
+
+// result - назначенные, затем допущенные кандидаты по рангу,
+// затем отброшенные в порядке рассмотрения
+func (t *assignmentTrace) result() []models.CandidateDecision {
+	if t == nil {
+		return nil
+	}
+
+	result := make([]models.CandidateDecision, 0, len(t.order))
+	for _, userID := range t.order {
+		result = append(result, *t.decisions[userID])
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Selected != b.Selected {
+			return a.Selected
+		}
+		if a.Accepted != b.Accepted {
+			return a.Accepted
+		}
+		if a.Rank == 0 || b.Rank == 0 {
+			return a.Rank != 0 && b.Rank == 0
+		}
+		return a.Rank < b.Rank
+	})
+
+	return result
+}
+
+func (t *assignmentTrace) decision(userID string) *models.CandidateDecision {
+	d, ok := t.decisions[userID]
+	if !ok {
+		d = &models.CandidateDecision{UserID: userID, Accepted: true, Reasons: []string{}}
+		t.decisions[userID] = d
+		t.order = append(t.order, userID)
+	}
+	return d
+}
+
+func (t *assignmentTrace) addReason(d *models.CandidateDecision, reason string) {
+	if !slices.Contains(d.Reasons, reason) {
+		d.Reasons = append(d.Reasons, reason)
+	}
+}
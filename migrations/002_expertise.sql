@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS pr_files (
+    pr_id VARCHAR(50) NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    path VARCHAR(1024) NOT NULL,
+    PRIMARY KEY (pr_id, path)
+);
+
+CREATE TABLE IF NOT EXISTS user_expertise (
+    user_id VARCHAR(50) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    pattern VARCHAR(255) NOT NULL,
+    PRIMARY KEY (user_id, pattern)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS user_expertise;
+DROP TABLE IF EXISTS pr_files;
@@ -0,0 +1,15 @@
+-- +goose Up
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS repository VARCHAR(255) NULL;
+
+CREATE TABLE IF NOT EXISTS codeowners (
+    repository VARCHAR(255) PRIMARY KEY,
+    content TEXT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_repository ON pull_requests(repository);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_pull_requests_repository;
+DROP TABLE IF EXISTS codeowners;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS repository;
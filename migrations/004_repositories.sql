@@ -0,0 +1,38 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS repositories (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) UNIQUE NOT NULL,
+    default_reviewer_count INTEGER NOT NULL DEFAULT 2 CHECK (default_reviewer_count >= 0),
+    team_id UUID NULL REFERENCES teams(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Репозитории, уже упомянутые в PR и CODEOWNERS
+INSERT INTO repositories (name)
+SELECT DISTINCT repository FROM pull_requests WHERE repository IS NOT NULL
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO repositories (name)
+SELECT repository FROM codeowners
+ON CONFLICT (name) DO NOTHING;
+
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS number INTEGER NULL;
+
+ALTER TABLE pull_requests
+    ADD CONSTRAINT fk_pull_requests_repository
+    FOREIGN KEY (repository) REFERENCES repositories(name) ON UPDATE CASCADE;
+
+ALTER TABLE codeowners
+    ADD CONSTRAINT fk_codeowners_repository
+    FOREIGN KEY (repository) REFERENCES repositories(name) ON UPDATE CASCADE ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pull_requests_repository_number ON pull_requests(repository, number);
+CREATE INDEX IF NOT EXISTS idx_repositories_name ON repositories(name);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_repositories_name;
+DROP INDEX IF EXISTS idx_pull_requests_repository_number;
+ALTER TABLE codeowners DROP CONSTRAINT IF EXISTS fk_codeowners_repository;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS fk_pull_requests_repository;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS number;
+DROP TABLE IF EXISTS repositories;
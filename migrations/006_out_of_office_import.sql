@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE user_out_of_office ADD COLUMN IF NOT EXISTS external_uid VARCHAR(255) NULL;
+
+-- Одно событие календаря может касаться нескольких участников
+ALTER TABLE user_out_of_office
+    ADD CONSTRAINT uq_user_out_of_office_external_uid UNIQUE (user_id, external_uid);
+
+CREATE INDEX IF NOT EXISTS idx_user_out_of_office_external_uid ON user_out_of_office(external_uid);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_user_out_of_office_external_uid;
+ALTER TABLE user_out_of_office DROP CONSTRAINT IF EXISTS uq_user_out_of_office_external_uid;
+ALTER TABLE user_out_of_office DROP COLUMN IF EXISTS external_uid;
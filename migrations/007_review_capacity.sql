@@ -0,0 +1,16 @@
+-- +goose Up
+-- NULL - без ограничения
+ALTER TABLE users ADD COLUMN IF NOT EXISTS max_open_reviews INTEGER NULL CHECK (max_open_reviews >= 0);
+
+-- PR, которым не хватило ревьюеров из-за загрузки команды
+CREATE TABLE IF NOT EXISTS pending_assignments (
+    pr_id VARCHAR(50) PRIMARY KEY REFERENCES pull_requests(id) ON DELETE CASCADE,
+    desired_count INTEGER NOT NULL,
+    queued_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pending_assignments_queued_at ON pending_assignments(queued_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS pending_assignments;
+ALTER TABLE users DROP COLUMN IF EXISTS max_open_reviews;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE pending_assignments ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE pending_assignments ADD COLUMN IF NOT EXISTS last_attempt_at TIMESTAMP WITH TIME ZONE NULL;
+
+-- +goose Down
+ALTER TABLE pending_assignments DROP COLUMN IF EXISTS last_attempt_at;
+ALTER TABLE pending_assignments DROP COLUMN IF EXISTS attempts;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Относительная вероятность выбора ревьюером: 1 - обычная, 0.5 - вдвое реже, 0 - только если больше некого
+ALTER TABLE users ADD COLUMN IF NOT EXISTS review_weight DOUBLE PRECISION NOT NULL DEFAULT 1 CHECK (review_weight >= 0);
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN IF EXISTS review_weight;
@@ -0,0 +1,14 @@
+-- +goose Up
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS assignment_strategy VARCHAR(20) NOT NULL DEFAULT 'random'
+    CHECK (assignment_strategy IN ('random', 'round_robin'));
+
+-- Позиция round-robin: последний назначенный пользователь команды
+CREATE TABLE IF NOT EXISTS team_assignment_cursors (
+    team_id UUID PRIMARY KEY REFERENCES teams(id) ON DELETE CASCADE,
+    last_user_id VARCHAR(50) NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS team_assignment_cursors;
+ALTER TABLE teams DROP COLUMN IF EXISTS assignment_strategy;
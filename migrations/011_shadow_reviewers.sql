@@ -0,0 +1,17 @@
+-- +goose Up
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_trainee BOOLEAN NOT NULL DEFAULT false;
+
+-- Стажеры, наблюдающие за ревью; не блокируют PR и не входят в число ревьюеров
+CREATE TABLE IF NOT EXISTS pr_shadow_reviewers (
+    pr_id VARCHAR(50) NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    user_id VARCHAR(50) NOT NULL REFERENCES users(id),
+    mentor_id VARCHAR(50) NULL REFERENCES users(id),
+    assigned_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (pr_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_shadow_reviewers_user_id ON pr_shadow_reviewers(user_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS pr_shadow_reviewers;
+ALTER TABLE users DROP COLUMN IF EXISTS is_trainee;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Правила подбора ревьюеров для конкретного автора:
+-- EXCLUDE - никогда не назначать reviewer_id на PR автора author_id,
+-- PREFER - выбирать reviewer_id раньше остальных кандидатов
+CREATE TABLE IF NOT EXISTS assignment_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('EXCLUDE', 'PREFER')),
+    author_id VARCHAR(50) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reviewer_id VARCHAR(50) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason VARCHAR(255) NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (kind, author_id, reviewer_id),
+    CHECK (author_id <> reviewer_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_assignment_rules_author_id ON assignment_rules(author_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS assignment_rules;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- Объяснения назначений: почему PR получил именно этих ревьюеров.
+-- Пишутся при создании PR, доборе из очереди и переназначении.
+CREATE TABLE IF NOT EXISTS pr_assignment_explanations (
+    id BIGSERIAL PRIMARY KEY,
+    pr_id VARCHAR(50) NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    event VARCHAR(20) NOT NULL,
+    explanation JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_assignment_explanations_pr_id ON pr_assignment_explanations(pr_id, id);
+
+-- +goose Down
+DROP TABLE IF EXISTS pr_assignment_explanations;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Постраничный список ревью пользователя: keyset по (время, id PR)
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_user_assigned ON pr_reviewers(user_id, assigned_at, pr_id);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_created_at ON pull_requests(created_at, id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_pull_requests_created_at;
+DROP INDEX IF EXISTS idx_pr_reviewers_user_assigned;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- Полнотекстовый поиск по названиям PR. Конфигурация simple - без стемминга,
+-- названия бывают и на русском, и на английском.
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS title_tsv tsvector
+    GENERATED ALWAYS AS (to_tsvector('simple', title)) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_title_tsv ON pull_requests USING GIN (title_tsv);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_merged_at ON pull_requests(merged_at, id) WHERE merged_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_pull_requests_merged_at;
+DROP INDEX IF EXISTS idx_pull_requests_title_tsv;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS title_tsv;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Журнал событий PR для потока /events: id - позиция в потоке (Last-Event-ID).
+-- Вставки сериализуются advisory-локом, поэтому id растут в порядке фиксации
+-- и клиент, продолживший с последнего id, ничего не пропускает.
+CREATE TABLE IF NOT EXISTS pr_events (
+    id BIGSERIAL PRIMARY KEY,
+    type VARCHAR(32) NOT NULL,
+    pr_id VARCHAR(50) NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    -- Участники события (автор, ревьюеры) и их команды - для фильтров потока
+    user_ids VARCHAR(50)[] NOT NULL,
+    team_names VARCHAR(255)[] NOT NULL,
+    data JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_events_user_ids ON pr_events USING GIN (user_ids);
+CREATE INDEX IF NOT EXISTS idx_pr_events_team_names ON pr_events USING GIN (team_names);
+
+-- +goose Down
+DROP TABLE IF EXISTS pr_events;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- Ответы на POST-запросы с заголовком Idempotency-Key.
+-- status_code IS NULL - первый запрос с этим ключом еще выполняется.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key VARCHAR(255) PRIMARY KEY,
+    -- sha256 метода, пути и тела запроса
+    request_hash CHAR(64) NOT NULL,
+    status_code INTEGER NULL,
+    response_headers JSONB NULL,
+    response_body BYTEA NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS idempotency_keys;
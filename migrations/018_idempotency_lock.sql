@@ -0,0 +1,7 @@
+-- +goose Up
+-- Время последнего продления блокировки ключа. Выполняющийся запрос продлевает
+-- ее, поэтому снова занять ключ можно только после падения реплики.
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS locked_at TIMESTAMP WITH TIME ZONE DEFAULT NOW();
+
+-- +goose Down
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS locked_at;
@@ -0,0 +1,9 @@
+// Package migrations встраивает SQL-миграции схемы в бинарник.
+// Файлы в формате goose (-- +goose Up / -- +goose Down), поэтому
+// их можно применять и самим goose (migration.sh).
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS